@@ -26,7 +26,7 @@ func main() {
 	logger := lager.NewLogger("route-pod-informer")
 	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
 
-	routeEmitter, err := route.NewEmitterFromConfig(cfg.NatsIP, cfg.NatsPort, cfg.NatsPassword, logger)
+	routeEmitter, err := route.NewEmitter(cfg.RouteEmitter, logger)
 	cmdcommons.ExitWithError(err)
 
 	clientset := cmdcommons.CreateKubeClient(cfg.ConfigPath)
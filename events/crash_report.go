@@ -0,0 +1,47 @@
+package events
+
+import (
+	"code.cloudfoundry.org/runtimeschema/cc_messages"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CrashReport describes a single app-instance crash as observed on the
+// Kubernetes cluster, translated into the shape Cloud Controller expects.
+type CrashReport struct {
+	ProcessGUID       string
+	AppCrashedRequest cc_messages.AppCrashedRequest
+
+	// PodNamespace and PodUID identify the Kubernetes pod this report was
+	// generated from, so a sink that writes back to the cluster (e.g. a
+	// Kubernetes Event) knows what to attach the event to.
+	PodNamespace string
+	PodUID       types.UID
+
+	// LastTerminationMessage is the container's LastState.Terminated.Message,
+	// when the container runtime or a termination-message-path wrote one.
+	LastTerminationMessage string
+
+	// LogTail holds the last few lines of the crashed container's log
+	// stream, best-effort, to save an operator a round trip to `kubectl logs`.
+	LogTail []string
+
+	// ContainerType is "init" when this report was generated from a pod's
+	// init container rather than its app container, so operators can tell
+	// a failing buildpack/credential-fetching init apart from an app
+	// crash. Empty for an app-container crash.
+	ContainerType string
+
+	// ContainerName is the name of the container this report was
+	// generated from.
+	ContainerName string
+
+	// CrashDiagnostic carries the Reason and Message of the pod's most
+	// recent Warning event (e.g. BackOff, FailedMount, Unhealthy), so
+	// consumers of the crash stream see the underlying cause without a
+	// separate API round trip. Empty if no Warning event was found.
+	CrashDiagnostic string
+
+	// CrashDiagnosticSource is the reporting component (e.g. "kubelet")
+	// of the event CrashDiagnostic was taken from.
+	CrashDiagnosticSource string
+}
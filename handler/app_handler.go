@@ -0,0 +1,295 @@
+// Package handler wires the Cloud Controller-facing HTTP API onto the
+// Bifrost, translating CC request/response shapes into calls against
+// Kubernetes.
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/lager"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/julienschmidt/httprouter"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Identifier addresses a single app version, the unit the Bifrost operates
+// on.
+type Identifier struct {
+	GUID    string
+	Version string
+}
+
+//go:generate counterfeiter . Bifrost
+
+// Bifrost is the bridge between the Cloud Controller API surface and
+// Kubernetes.
+type Bifrost interface {
+	Transfer(ctx context.Context, request cf.DesireLRPRequest) error
+	List(ctx context.Context) ([]*models.DesiredLRPSchedulingInfo, error)
+	GetApp(ctx context.Context, identifier Identifier) (*models.DesiredLRP, error)
+	GetInstances(ctx context.Context, identifier Identifier) ([]*cf.Instance, error)
+	// Update applies a (possibly partial) rolling update to an app and
+	// returns a rollout ID that RolloutStatus can later be polled with.
+	Update(ctx context.Context, request cf.UpdateDesiredLRPRequest) (string, error)
+	RolloutStatus(ctx context.Context, identifier Identifier, rolloutID string) (*cf.RolloutStatus, error)
+	Stop(ctx context.Context, identifier Identifier) error
+	StopInstance(ctx context.Context, identifier Identifier, index uint) error
+	// Logs streams the log output of a single instance. When follow is
+	// true the returned ReadCloser keeps delivering new output until the
+	// caller closes it; tailLines, if positive, seeds it with that many
+	// lines of history first.
+	Logs(ctx context.Context, identifier Identifier, index uint, follow bool, tailLines int64) (io.ReadCloser, error)
+	// Exec runs cmd inside a single instance's container, proxying the
+	// already-negotiated stdin/stdout/stderr/resize streams to it. It
+	// blocks until the command exits or ctx is cancelled.
+	Exec(ctx context.Context, identifier Identifier, index uint, cmd []string, streams remotecommand.StreamOptions) error
+	// PortForward tunnels a single connection to port inside a running
+	// instance, copying bytes to and from stream until either side
+	// closes it.
+	PortForward(ctx context.Context, identifier Identifier, index uint, port int32, stream io.ReadWriteCloser) error
+}
+
+//go:generate counterfeiter . Stager
+
+// Stager handles staging requests; its endpoints live in staging_handler.go.
+// New accepts a Stager but registers none of its endpoints.
+type Stager interface {
+}
+
+// App serves the app lifecycle endpoints (desire/list/get/update/stop) on
+// behalf of Cloud Controller.
+type App struct {
+	Bifrost       Bifrost
+	Authenticator Authenticator
+	Logger        lager.Logger
+}
+
+// NewAppHandler constructs an App ready to be registered on a router.
+func NewAppHandler(bifrost Bifrost, authenticator Authenticator, logger lager.Logger) *App {
+	return &App{
+		Bifrost:       bifrost,
+		Authenticator: authenticator,
+		Logger:        logger,
+	}
+}
+
+// New builds the HTTP handler for the app lifecycle, rollout, logs, exec
+// and port-forward endpoints. No staging endpoints are registered; stager
+// is not used.
+func New(bifrost Bifrost, stager Stager, authenticator Authenticator, logger lager.Logger) http.Handler {
+	appHandler := NewAppHandler(bifrost, authenticator, logger)
+
+	router := httprouter.New()
+	router.PUT("/apps/:guid", appHandler.Desire)
+	router.GET("/apps", appHandler.List)
+	router.GET("/apps/:guid/:version", appHandler.GetApp)
+	router.GET("/apps/:guid/:version/instances", appHandler.GetInstances)
+	router.POST("/apps/:guid", appHandler.Update)
+	router.GET("/apps/:guid/:version/rollout/:id", appHandler.Rollout)
+	router.PUT("/apps/:guid/:version/stop", appHandler.Stop)
+	router.PUT("/apps/:guid/:version/stop/:index", appHandler.StopInstance)
+	router.GET("/apps/:guid/:version/instances/:index/logs", appHandler.Logs)
+	router.POST("/apps/:guid/:version/exec/:index", appHandler.Exec)
+	router.POST("/apps/:guid/:version/portforward/:index", appHandler.PortForward)
+
+	return router
+}
+
+func (a *App) Desire(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	guid := ps.ByName("guid")
+	logger := a.Logger.Session("desire-app", lager.Data{"guid": guid})
+
+	body, err := readBody(r)
+	if err != nil || len(body) == 0 {
+		logger.Error("request-body-decoding-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request cf.DesireLRPRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		logger.Error("request-body-decoding-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	request.LRP = string(body)
+
+	if err := a.Bifrost.Transfer(r.Context(), request); err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *App) List(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	logger := a.Logger.Session("list-apps")
+
+	schedInfos, err := a.Bifrost.List(r.Context())
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := models.DesiredLRPSchedulingInfosResponse{
+		DesiredLrpSchedulingInfos: schedInfos,
+	}
+
+	writeProtoJSON(w, &response)
+}
+
+func (a *App) GetApp(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("get-app", lager.Data{"guid": identifier.GUID})
+
+	desiredLRP, err := a.Bifrost.GetApp(r.Context(), identifier)
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	response := models.DesiredLRPResponse{DesiredLrp: desiredLRP}
+	writeProtoJSON(w, &response)
+}
+
+func (a *App) GetInstances(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("get-app-instances", lager.Data{"guid": identifier.GUID})
+
+	instances, err := a.Bifrost.GetInstances(r.Context(), identifier)
+
+	response := struct {
+		ProcessGUID string         `json:"process_guid"`
+		Instances   []*cf.Instance `json:"instances"`
+		Error       string         `json:"error,omitempty"`
+	}{
+		ProcessGUID: fmt.Sprintf("%s-%s", identifier.GUID, identifier.Version),
+		Instances:   instances,
+	}
+
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		response.Error = err.Error()
+	}
+
+	writeJSON(w, response)
+}
+
+func (a *App) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("update-app", lager.Data{"guid": identifier.GUID})
+
+	body, err := readBody(r)
+	var request cf.UpdateDesiredLRPRequest
+	if err != nil || json.Unmarshal(body, &request) != nil {
+		logger.Error("json-decoding-failed", err)
+		writeLifecycleError(w, http.StatusBadRequest, "failed to decode update request")
+		return
+	}
+
+	rolloutID, err := a.Bifrost.Update(r.Context(), request)
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		writeLifecycleError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, struct {
+		RolloutID string `json:"rollout_id"`
+	}{RolloutID: rolloutID})
+}
+
+// Rollout reports the progress of a rolling update previously started by
+// Update, so Cloud Controller can drive canary-style deploys by polling
+// instance-by-instance readiness instead of only changing instance counts.
+func (a *App) Rollout(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	rolloutID := ps.ByName("id")
+	logger := a.Logger.Session("rollout-status", lager.Data{"guid": identifier.GUID, "rollout-id": rolloutID})
+
+	status, err := a.Bifrost.RolloutStatus(r.Context(), identifier, rolloutID)
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, status)
+}
+
+func (a *App) Stop(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("stop-app", lager.Data{"guid": identifier.GUID})
+
+	if err := a.Bifrost.Stop(r.Context(), identifier); err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *App) StopInstance(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("stop-app-instance", lager.Data{"guid": identifier.GUID})
+
+	index, err := strconv.ParseUint(ps.ByName("index"), 10, 64)
+	if err != nil {
+		logger.Error("parsing-instance-index-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := a.Bifrost.StopInstance(r.Context(), identifier, uint(index)); err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func identifierFromParams(ps httprouter.Params) Identifier {
+	return Identifier{
+		GUID:    ps.ByName("guid"),
+		Version: ps.ByName("version"),
+	}
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+	return ioutil.ReadAll(r.Body)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeProtoJSON(w http.ResponseWriter, message proto.Message) {
+	w.Header().Set("Content-Type", "application/json")
+	marshaler := jsonpb.Marshaler{Indent: "", OrigName: true}
+	_ = marshaler.Marshal(w, message)
+}
+
+func writeLifecycleError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	response := models.DesiredLRPLifecycleResponse{
+		Error: &models.Error{Message: message},
+	}
+	writeProtoJSON(w, &response)
+}
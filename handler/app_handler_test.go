@@ -23,14 +23,16 @@ import (
 
 var _ = Describe("AppHandler", func() {
 	var (
-		bifrost *eirinifakes.FakeBifrost
-		stager  *eirinifakes.FakeStager
-		lager   *lagertest.TestLogger
+		bifrost       *eirinifakes.FakeBifrost
+		stager        *eirinifakes.FakeStager
+		authenticator *eirinifakes.FakeAuthenticator
+		lager         *lagertest.TestLogger
 	)
 
 	BeforeEach(func() {
 		bifrost = new(eirinifakes.FakeBifrost)
 		stager = new(eirinifakes.FakeStager)
+		authenticator = new(eirinifakes.FakeAuthenticator)
 		lager = lagertest.NewTestLogger("app-handler-test")
 	})
 
@@ -97,7 +99,7 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		JustBeforeEach(func() {
-			ts := httptest.NewServer(New(bifrost, stager, lager))
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
 			req, err := http.NewRequest("PUT", ts.URL+path, bytes.NewReader([]byte(body)))
 			Expect(err).NotTo(HaveOccurred())
 
@@ -198,7 +200,7 @@ var _ = Describe("AppHandler", func() {
 			req, err := http.NewRequest("", "/apps", nil)
 			Expect(err).ToNot(HaveOccurred())
 			responseRecorder = httptest.NewRecorder()
-			appHandler = NewAppHandler(bifrost, lager)
+			appHandler = NewAppHandler(bifrost, authenticator, lager)
 			appHandler.List(responseRecorder, req, httprouter.Params{})
 			expectedResponse := models.DesiredLRPSchedulingInfosResponse{
 				DesiredLrpSchedulingInfos: schedInfos,
@@ -271,7 +273,7 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		JustBeforeEach(func() {
-			ts := httptest.NewServer(New(bifrost, stager, lager))
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
 			req, err := http.NewRequest("GET", ts.URL+path, nil)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -342,7 +344,7 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		JustBeforeEach(func() {
-			ts := httptest.NewServer(New(bifrost, stager, lager))
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
 			req, err := http.NewRequest("GET", ts.URL+path, nil)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -429,7 +431,7 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		JustBeforeEach(func() {
-			ts := httptest.NewServer(New(bifrost, stager, lager))
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
 			req, err := http.NewRequest("POST", ts.URL+path, bytes.NewReader([]byte(body)))
 			Expect(err).NotTo(HaveOccurred())
 
@@ -440,11 +442,17 @@ var _ = Describe("AppHandler", func() {
 
 		Context("when the update is successful", func() {
 			BeforeEach(func() {
-				bifrost.UpdateReturns(nil)
+				bifrost.UpdateReturns("rollout-1", nil)
 			})
 
-			It("should return a 200 HTTP stauts code", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			It("should return a 202 Accepted HTTP status code with the rollout id", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+
+				var responseObj struct {
+					RolloutID string `json:"rollout_id"`
+				}
+				Expect(json.NewDecoder(response.Body).Decode(&responseObj)).To(Succeed())
+				Expect(responseObj.RolloutID).To(Equal("rollout-1"))
 			})
 
 			It("should translate the request", func() {
@@ -478,7 +486,7 @@ var _ = Describe("AppHandler", func() {
 
 		Context("when update fails", func() {
 			BeforeEach(func() {
-				bifrost.UpdateReturns(errors.New("Failed to update"))
+				bifrost.UpdateReturns("", errors.New("Failed to update"))
 			})
 
 			It("should return a 500 HTTP status code", func() {
@@ -493,6 +501,67 @@ var _ = Describe("AppHandler", func() {
 		})
 	})
 
+	Context("Rollout status", func() {
+		var (
+			path     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			path = "/apps/app_1234/version_1234/rollout/rollout-1"
+		})
+
+		JustBeforeEach(func() {
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
+			req, err := http.NewRequest("GET", ts.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			client := &http.Client{}
+			response, err = client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the rollout is in progress", func() {
+			BeforeEach(func() {
+				bifrost.RolloutStatusReturns(&cf.RolloutStatus{
+					ID:       "rollout-1",
+					Complete: false,
+					Instances: []cf.InstanceRollout{
+						{Index: 0, State: cf.RolloutStateReady},
+						{Index: 1, State: cf.RolloutStateUpdating},
+					},
+				}, nil)
+			})
+
+			It("should return a 200 HTTP status code with the rollout progress", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var status cf.RolloutStatus
+				Expect(json.NewDecoder(response.Body).Decode(&status)).To(Succeed())
+				Expect(status.Complete).To(BeFalse())
+				Expect(status.Instances).To(HaveLen(2))
+			})
+
+			It("should ask the bifrost for the right rollout", func() {
+				Expect(bifrost.RolloutStatusCallCount()).To(Equal(1))
+				_, identifier, rolloutID := bifrost.RolloutStatusArgsForCall(0)
+				Expect(identifier.GUID).To(Equal("app_1234"))
+				Expect(identifier.Version).To(Equal("version_1234"))
+				Expect(rolloutID).To(Equal("rollout-1"))
+			})
+		})
+
+		Context("when the rollout is unknown", func() {
+			BeforeEach(func() {
+				bifrost.RolloutStatusReturns(nil, errors.New("no such rollout"))
+			})
+
+			It("should return a 404 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	Context("Stop an app", func() {
 		var (
 			path     string
@@ -504,7 +573,7 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		JustBeforeEach(func() {
-			ts := httptest.NewServer(New(bifrost, stager, lager))
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
 			req, err := http.NewRequest("PUT", ts.URL+path, nil)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -552,7 +621,7 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		JustBeforeEach(func() {
-			ts := httptest.NewServer(New(bifrost, stager, lager))
+			ts := httptest.NewServer(New(bifrost, stager, authenticator, lager))
 			req, err := http.NewRequest("PUT", ts.URL+path, nil)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+//go:generate counterfeiter . Authenticator
+
+// Authenticator validates the bearer token on requests that open a direct
+// channel into a container (exec, port-forward) rather than going through
+// Cloud Controller.
+type Authenticator interface {
+	Authenticate(r *http.Request) bool
+}
+
+// StaticTokenAuthenticator authenticates requests against a single
+// pre-shared bearer token, for deployments that don't run an OIDC issuer.
+type StaticTokenAuthenticator struct {
+	Token string
+}
+
+// Authenticate reports whether r carries the configured bearer token. An
+// empty configured token never authenticates, so the zero value is safe.
+func (a StaticTokenAuthenticator) Authenticate(r *http.Request) bool {
+	return a.Token != "" && bearerToken(r) == a.Token
+}
+
+//go:generate counterfeiter . OIDCTokenVerifier
+
+// OIDCTokenVerifier verifies a bearer token against an OIDC issuer, e.g.
+// an *oidc.IDTokenVerifier from github.com/coreos/go-oidc wrapped to
+// return only the error.
+type OIDCTokenVerifier interface {
+	Verify(ctx context.Context, rawToken string) error
+}
+
+// OIDCAuthenticator authenticates requests against an OIDC issuer instead
+// of a single static token.
+type OIDCAuthenticator struct {
+	Verifier OIDCTokenVerifier
+}
+
+// Authenticate reports whether r carries a bearer token the configured
+// OIDC issuer accepts.
+func (a OIDCAuthenticator) Authenticate(r *http.Request) bool {
+	token := bearerToken(r)
+	if token == "" {
+		return false
+	}
+
+	return a.Verifier.Verify(r.Context(), token) == nil
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(header, prefix)
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/julienschmidt/httprouter"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	streamTypeHeader = "streamType"
+	streamTypeStdin  = "stdin"
+	streamTypeStdout = "stdout"
+	streamTypeStderr = "stderr"
+	streamTypeError  = "error"
+	streamTypeResize = "resize"
+
+	execStreamCreationTimeout = 30 * time.Second
+
+	// execSessionIdleTimeout bounds how long an established exec session may
+	// sit without traffic before the SPDY connection is torn down. It is much
+	// longer than execStreamCreationTimeout, which only needs to cover the
+	// initial stream handshake: an interactive `cf ssh`-style session can
+	// otherwise go quiet for long stretches (a human staring at a prompt)
+	// without the connection being considered dead.
+	execSessionIdleTimeout = 1 * time.Hour
+)
+
+// supportedStreamProtocols are offered to the client in preference order
+// during the SPDY handshake; v4 adds exit-code reporting over the error
+// stream, v1 has neither that nor resize support.
+var supportedStreamProtocols = []string{
+	"v4.channel.k8s.io",
+	"v3.channel.k8s.io",
+	"v2.channel.k8s.io",
+	"channel.k8s.io",
+}
+
+// execStreams are the negotiated stdin/stdout/stderr/resize/error streams
+// for a single exec session, assembled from whichever sub-streams the
+// client opens over the SPDY connection.
+type execStreams struct {
+	stdin       io.Reader
+	stdout      io.Writer
+	stderr      io.Writer
+	errorStream io.Writer
+	resizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Exec upgrades the connection to SPDY and proxies the negotiated
+// stdin/stdout/stderr/resize streams to a single instance's container,
+// giving `cf ssh` semantics without a Diego SSH proxy.
+func (a *App) Exec(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("exec", lager.Data{"guid": identifier.GUID})
+
+	if !a.Authenticator.Authenticate(r) {
+		logger.Info("unauthenticated")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	index, err := strconv.ParseUint(ps.ByName("index"), 10, 64)
+	if err != nil {
+		logger.Error("parsing-instance-index-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	cmd := r.URL.Query()["command"]
+	if len(cmd) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	tty := r.URL.Query().Get("tty") == "true"
+
+	protocol, err := httpstream.Handshake(r, w, supportedStreamProtocols)
+	if err != nil {
+		logger.Error("protocol-negotiation-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	streamCh := make(chan httpstream.Stream)
+
+	upgrader := spdy.NewResponseUpgrader()
+	conn := upgrader.UpgradeResponse(w, r, func(stream httpstream.Stream, _ <-chan struct{}) error {
+		streamCh <- stream
+		return nil
+	})
+	if conn == nil {
+		logger.Error("spdy-upgrade-failed", nil)
+		return
+	}
+	defer conn.Close()
+	conn.SetIdleTimeout(execStreamCreationTimeout)
+
+	streams, err := waitForExecStreams(streamCh, tty, protocol)
+	if err != nil {
+		logger.Error("failed-to-negotiate-streams", err)
+		return
+	}
+	conn.SetIdleTimeout(execSessionIdleTimeout)
+
+	opts := remotecommand.StreamOptions{
+		Stdin:             streams.stdin,
+		Stdout:            streams.stdout,
+		Stderr:            streams.stderr,
+		Tty:               tty,
+		TerminalSizeQueue: streams.resizeQueue,
+	}
+
+	if err := a.Bifrost.Exec(r.Context(), identifier, uint(index), cmd, opts); err != nil {
+		logger.Error("bifrost-failed", err)
+		fmt.Fprint(streams.errorStream, err.Error())
+	}
+}
+
+// waitForExecStreams blocks until the client has opened stdin, stdout and
+// error streams (stderr too, unless tty multiplexes it into stdout), or
+// execStreamCreationTimeout elapses.
+func waitForExecStreams(streamCh <-chan httpstream.Stream, tty bool, protocol string) (*execStreams, error) {
+	streams := &execStreams{}
+
+	wantStderr := !tty
+	haveStdin, haveStdout, haveStderr, haveError := false, false, !wantStderr, false
+
+	timeout := time.After(execStreamCreationTimeout)
+
+	for !(haveStdin && haveStdout && haveStderr && haveError) {
+		select {
+		case stream := <-streamCh:
+			switch stream.Headers().Get(streamTypeHeader) {
+			case streamTypeStdin:
+				streams.stdin = stream
+				haveStdin = true
+			case streamTypeStdout:
+				streams.stdout = stream
+				haveStdout = true
+			case streamTypeStderr:
+				streams.stderr = stream
+				haveStderr = true
+			case streamTypeError:
+				streams.errorStream = stream
+				haveError = true
+			case streamTypeResize:
+				streams.resizeQueue = newResizeQueue(stream)
+			}
+		case <-timeout:
+			return nil, fmt.Errorf("timed out waiting for exec streams, protocol %q", protocol)
+		}
+	}
+
+	return streams, nil
+}
+
+// resizeQueue adapts a stream of JSON-encoded TerminalSize messages into a
+// remotecommand.TerminalSizeQueue.
+type resizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+func newResizeQueue(stream io.Reader) *resizeQueue {
+	q := &resizeQueue{sizes: make(chan remotecommand.TerminalSize)}
+	go q.readLoop(stream)
+
+	return q
+}
+
+func (q *resizeQueue) readLoop(stream io.Reader) {
+	defer close(q.sizes)
+
+	decoder := json.NewDecoder(stream)
+	for {
+		var size remotecommand.TerminalSize
+		if err := decoder.Decode(&size); err != nil {
+			return
+		}
+
+		q.sizes <- size
+	}
+}
+
+func (q *resizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+
+	return &size
+}
@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"code.cloudfoundry.org/eirini/eirinifakes"
+	. "code.cloudfoundry.org/eirini/handler"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// execProtocol is the stream protocol negotiated in these tests; it must
+// be one Exec offers via supportedStreamProtocols.
+const execProtocol = "v4.channel.k8s.io"
+
+// dialExec performs the client side of the SPDY handshake against path,
+// mirroring what remotecommand.NewSPDYExecutor does internally, so the
+// tests can drive stream creation directly.
+func dialExec(server *httptest.Server, path string) (httpstream.Connection, string, error) {
+	reqURL, err := url.Parse(server.URL + path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	transport, upgrader := spdy.NewRoundTripper(nil, true, true)
+	client := &http.Client{Transport: transport}
+
+	dialer := spdy.NewDialer(upgrader, client, http.MethodPost, reqURL)
+	return dialer.Dial(execProtocol)
+}
+
+func createExecStream(conn httpstream.Connection, streamType string) (httpstream.Stream, error) {
+	headers := http.Header{}
+	headers.Set("streamType", streamType)
+	return conn.CreateStream(headers)
+}
+
+var _ = Describe("Exec", func() {
+	var (
+		bifrost       *eirinifakes.FakeBifrost
+		authenticator *eirinifakes.FakeAuthenticator
+		logger        *lagertest.TestLogger
+		server        *httptest.Server
+	)
+
+	BeforeEach(func() {
+		bifrost = new(eirinifakes.FakeBifrost)
+		authenticator = new(eirinifakes.FakeAuthenticator)
+		authenticator.AuthenticateReturns(true)
+		logger = lagertest.NewTestLogger("exec-test")
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(New(bifrost, new(eirinifakes.FakeStager), authenticator, logger))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the client negotiates stdin, stdout, stderr and error streams", func() {
+		It("calls the bifrost once all four streams have arrived", func() {
+			conn, protocol, err := dialExec(server, "/apps/guid/version/exec/0?command=/bin/sh")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			Expect(protocol).To(Equal(execProtocol))
+
+			_, err = createExecStream(conn, "stdin")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createExecStream(conn, "stdout")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createExecStream(conn, "stderr")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createExecStream(conn, "error")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(bifrost.ExecCallCount).Should(Equal(1))
+			_, identifier, index, cmd, _ := bifrost.ExecArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid"))
+			Expect(identifier.Version).To(Equal("version"))
+			Expect(index).To(Equal(uint(0)))
+			Expect(cmd).To(Equal([]string{"/bin/sh"}))
+		})
+	})
+
+	Context("when the client negotiates a tty session", func() {
+		It("calls the bifrost once stdin, stdout and error have arrived, without a separate stderr stream", func() {
+			conn, _, err := dialExec(server, "/apps/guid/version/exec/0?command=/bin/sh&tty=true")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = createExecStream(conn, "stdin")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createExecStream(conn, "stdout")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createExecStream(conn, "error")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(bifrost.ExecCallCount).Should(Equal(1))
+			_, _, _, _, opts := bifrost.ExecArgsForCall(0)
+			Expect(opts.Tty).To(BeTrue())
+		})
+	})
+
+	Context("when the request carries no bearer token the authenticator accepts", func() {
+		BeforeEach(func() {
+			authenticator.AuthenticateReturns(false)
+		})
+
+		It("rejects the request before attempting the SPDY handshake", func() {
+			resp, err := http.Post(server.URL+"/apps/guid/version/exec/0?command=/bin/sh", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(bifrost.ExecCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when no command is given", func() {
+		It("returns a 400 without upgrading the connection", func() {
+			resp, err := http.Post(server.URL+"/apps/guid/version/exec/0", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the bifrost fails to run the command", func() {
+		BeforeEach(func() {
+			bifrost.ExecReturns(errors.New("container not found"))
+		})
+
+		It("writes the error to the negotiated error stream", func() {
+			conn, _, err := dialExec(server, "/apps/guid/version/exec/0?command=/bin/sh")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = createExecStream(conn, "stdin")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = createExecStream(conn, "stdout")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = createExecStream(conn, "stderr")
+			Expect(err).NotTo(HaveOccurred())
+
+			errorStream, err := createExecStream(conn, "error")
+			Expect(err).NotTo(HaveOccurred())
+
+			buf := make([]byte, 1024)
+			n, err := errorStream.Read(buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf[:n])).To(ContainSubstring("container not found"))
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+)
+
+// logsUpgrader upgrades a logs request to a WebSocket connection. Logs is
+// an internal, trusted API surface rather than a browser-facing one, so
+// the usual same-origin check doesn't apply.
+var logsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Logs streams the log output of a single app instance. A plain HTTP
+// request (e.g. `curl -N`) gets a chunked-transfer stream flushed after
+// every read; a request that asks for a WebSocket upgrade gets the same
+// bytes relayed as WebSocket messages instead, so the cf CLI can multiplex
+// `cf logs` over one connection without an intermediate loggregator.
+func (a *App) Logs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("stream-logs", lager.Data{"guid": identifier.GUID})
+
+	index, err := strconv.ParseUint(ps.ByName("index"), 10, 64)
+	if err != nil {
+		logger.Error("parsing-instance-index-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	tailLines, err := parseTailLines(r.URL.Query().Get("tail"))
+	if err != nil {
+		logger.Error("parsing-tail-lines-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	follow := r.URL.Query().Get("follow") == "true"
+
+	logStream, err := a.Bifrost.Logs(r.Context(), identifier, uint(index), follow, tailLines)
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	defer logStream.Close()
+
+	if websocket.IsWebSocketUpgrade(r) {
+		streamLogsOverWebSocket(logStream, w, r, logger)
+		return
+	}
+
+	streamLogsChunked(logStream, w, logger)
+}
+
+func parseTailLines(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+// streamLogsChunked copies logStream to w, flushing after every read so a
+// chunked-transfer client sees each write as it arrives instead of
+// buffered until the handler returns.
+func streamLogsChunked(logStream io.Reader, w http.ResponseWriter, logger lager.Logger) {
+	flusher, _ := w.(http.Flusher)
+
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := logStream.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				logger.Error("failed-to-write-chunk", err)
+				return
+			}
+
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+
+		if readErr != nil {
+			if readErr != io.EOF {
+				logger.Error("failed-to-read-log-stream", readErr)
+			}
+			return
+		}
+	}
+}
+
+// streamLogsOverWebSocket relays logStream as a series of WebSocket text
+// messages, one per read.
+func streamLogsOverWebSocket(logStream io.Reader, w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	conn, err := logsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error("websocket-upgrade-failed", err)
+		return
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := logStream.Read(buf)
+		if n > 0 {
+			if err := conn.WriteMessage(websocket.TextMessage, buf[:n]); err != nil {
+				logger.Error("failed-to-write-websocket-message", err)
+				return
+			}
+		}
+
+		if readErr != nil {
+			if readErr != io.EOF {
+				logger.Error("failed-to-read-log-stream", readErr)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/eirini/eirinifakes"
+	. "code.cloudfoundry.org/eirini/handler"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// chunkedReadCloser hands out one chunk per Read call, blocking until the
+// test sends the next one, so tests can observe that each chunk reaches
+// the client before the stream has finished.
+type chunkedReadCloser struct {
+	chunks chan []byte
+	closed chan struct{}
+}
+
+func newChunkedReadCloser() *chunkedReadCloser {
+	return &chunkedReadCloser{
+		chunks: make(chan []byte),
+		closed: make(chan struct{}),
+	}
+}
+
+func (c *chunkedReadCloser) Read(p []byte) (int, error) {
+	chunk, ok := <-c.chunks
+	if !ok {
+		return 0, io.EOF
+	}
+	return copy(p, chunk), nil
+}
+
+func (c *chunkedReadCloser) Close() error {
+	close(c.closed)
+	return nil
+}
+
+var _ = Describe("Logs", func() {
+	var (
+		bifrost *eirinifakes.FakeBifrost
+		logger  *lagertest.TestLogger
+		server  *httptest.Server
+	)
+
+	BeforeEach(func() {
+		bifrost = new(eirinifakes.FakeBifrost)
+		logger = lagertest.NewTestLogger("logs-test")
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(New(bifrost, new(eirinifakes.FakeStager), new(eirinifakes.FakeAuthenticator), logger))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("chunked streaming", func() {
+		var stream *chunkedReadCloser
+
+		BeforeEach(func() {
+			stream = newChunkedReadCloser()
+			bifrost.LogsReturns(stream, nil)
+		})
+
+		It("streams each chunk to the client as soon as it is read, without waiting for the full body", func() {
+			resp, err := http.Get(server.URL + "/apps/guid/version/instances/0/logs?follow=true")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			reader := bufio.NewReader(resp.Body)
+
+			stream.chunks <- []byte("first line\n")
+			line, err := reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(Equal("first line\n"))
+
+			stream.chunks <- []byte("second line\n")
+			line, err = reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(Equal("second line\n"))
+
+			close(stream.chunks)
+		})
+
+		It("passes the instance index, follow and tail parameters through to the bifrost", func() {
+			resp, err := http.Get(server.URL + "/apps/guid/version/instances/2/logs?follow=true&tail=50")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			close(stream.chunks)
+			_, _ = ioutil.ReadAll(resp.Body)
+
+			Expect(bifrost.LogsCallCount()).To(Equal(1))
+			_, identifier, index, follow, tailLines := bifrost.LogsArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid"))
+			Expect(identifier.Version).To(Equal("version"))
+			Expect(index).To(Equal(uint(2)))
+			Expect(follow).To(BeTrue())
+			Expect(tailLines).To(Equal(int64(50)))
+		})
+
+		It("closes the bifrost log stream once the request completes", func() {
+			resp, err := http.Get(server.URL + "/apps/guid/version/instances/0/logs")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			close(stream.chunks)
+			_, _ = ioutil.ReadAll(resp.Body)
+
+			Eventually(stream.closed).Should(BeClosed())
+		})
+	})
+
+	Context("when the pod does not exist", func() {
+		BeforeEach(func() {
+			bifrost.LogsReturns(nil, errors.New("pod not found"))
+		})
+
+		It("returns a 404", func() {
+			resp, err := http.Get(server.URL + "/apps/guid/version/instances/0/logs")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when the instance index is not a number", func() {
+		It("returns a 400", func() {
+			resp, err := http.Get(server.URL + "/apps/guid/version/instances/not-a-number/logs")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the tail parameter is not a number", func() {
+		It("returns a 400", func() {
+			resp, err := http.Get(server.URL + "/apps/guid/version/instances/0/logs?tail=soon")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("WebSocket upgrade", func() {
+		var stream *chunkedReadCloser
+
+		BeforeEach(func() {
+			stream = newChunkedReadCloser()
+			bifrost.LogsReturns(stream, nil)
+		})
+
+		It("relays log output as WebSocket messages", func() {
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/apps/guid/version/instances/0/logs"
+
+			conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			stream.chunks <- []byte("hello over websocket")
+
+			_, message, err := conn.ReadMessage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(message)).To(Equal("hello over websocket"))
+
+			close(stream.chunks)
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/julienschmidt/httprouter"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+)
+
+const (
+	portForwardProtocolV1Name  = "portforward.k8s.io"
+	portForwardRequestIDHeader = "requestID"
+	portHeader                 = "port"
+)
+
+// portForwardStreamPair is the data+error stream pair a port-forward
+// client opens for a single forwarded connection, tied together by a
+// shared requestID header.
+type portForwardStreamPair struct {
+	port        int32
+	dataStream  httpstream.Stream
+	errorStream httpstream.Stream
+}
+
+// portForwardStreamPairs matches up the data/error streams a port-forward
+// client opens per connection and hands each completed pair to handle.
+type portForwardStreamPairs struct {
+	handle func(pair portForwardStreamPair)
+
+	mutex sync.Mutex
+	pairs map[string]*portForwardStreamPair
+}
+
+func newPortForwardStreamPairs(handle func(pair portForwardStreamPair)) *portForwardStreamPairs {
+	return &portForwardStreamPairs{handle: handle, pairs: make(map[string]*portForwardStreamPair)}
+}
+
+func (s *portForwardStreamPairs) add(stream httpstream.Stream) error {
+	requestID := stream.Headers().Get(portForwardRequestIDHeader)
+
+	s.mutex.Lock()
+
+	pair, ok := s.pairs[requestID]
+	if !ok {
+		pair = &portForwardStreamPair{}
+		s.pairs[requestID] = pair
+	}
+
+	if stream.Headers().Get(streamTypeHeader) == streamTypeError {
+		pair.errorStream = stream
+	} else {
+		port, err := strconv.ParseInt(stream.Headers().Get(portHeader), 10, 32)
+		if err != nil {
+			s.mutex.Unlock()
+			return fmt.Errorf("failed to parse port forward port header: %w", err)
+		}
+
+		pair.port = int32(port)
+		pair.dataStream = stream
+	}
+
+	complete := pair.dataStream != nil && pair.errorStream != nil
+	if complete {
+		delete(s.pairs, requestID)
+	}
+
+	s.mutex.Unlock()
+
+	if complete {
+		go s.handle(*pair)
+	}
+
+	return nil
+}
+
+// PortForward upgrades the connection to SPDY and opens a local↔pod TCP
+// tunnel for each port the client requests, giving cf CLI-style
+// port-forwarding without a Diego SSH proxy.
+func (a *App) PortForward(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identifier := identifierFromParams(ps)
+	logger := a.Logger.Session("port-forward", lager.Data{"guid": identifier.GUID})
+
+	if !a.Authenticator.Authenticate(r) {
+		logger.Info("unauthenticated")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	index, err := strconv.ParseUint(ps.ByName("index"), 10, 64)
+	if err != nil {
+		logger.Error("parsing-instance-index-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if _, err := httpstream.Handshake(r, w, []string{portForwardProtocolV1Name}); err != nil {
+		logger.Error("protocol-negotiation-failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pairs := newPortForwardStreamPairs(func(pair portForwardStreamPair) {
+		defer pair.dataStream.Close()
+		defer pair.errorStream.Close()
+
+		if err := a.Bifrost.PortForward(r.Context(), identifier, uint(index), pair.port, pair.dataStream); err != nil {
+			logger.Error("bifrost-failed", err, lager.Data{"port": pair.port})
+			fmt.Fprint(pair.errorStream, err.Error())
+		}
+	})
+
+	upgrader := spdy.NewResponseUpgrader()
+	conn := upgrader.UpgradeResponse(w, r, func(stream httpstream.Stream, _ <-chan struct{}) error {
+		return pairs.add(stream)
+	})
+	if conn == nil {
+		logger.Error("spdy-upgrade-failed", nil)
+		return
+	}
+	defer conn.Close()
+
+	<-conn.CloseChan()
+}
@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"code.cloudfoundry.org/eirini/eirinifakes"
+	. "code.cloudfoundry.org/eirini/handler"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const portForwardProtocol = "portforward.k8s.io"
+
+func dialPortForward(server *httptest.Server, path string) (httpstream.Connection, string, error) {
+	reqURL, err := url.Parse(server.URL + path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	transport, upgrader := spdy.NewRoundTripper(nil, true, true)
+	client := &http.Client{Transport: transport}
+
+	dialer := spdy.NewDialer(upgrader, client, http.MethodPost, reqURL)
+	return dialer.Dial(portForwardProtocol)
+}
+
+func createPortForwardStream(conn httpstream.Connection, streamType, port, requestID string) (httpstream.Stream, error) {
+	headers := http.Header{}
+	headers.Set("streamType", streamType)
+	headers.Set("port", port)
+	headers.Set("requestID", requestID)
+	return conn.CreateStream(headers)
+}
+
+var _ = Describe("PortForward", func() {
+	var (
+		bifrost       *eirinifakes.FakeBifrost
+		authenticator *eirinifakes.FakeAuthenticator
+		logger        *lagertest.TestLogger
+		server        *httptest.Server
+	)
+
+	BeforeEach(func() {
+		bifrost = new(eirinifakes.FakeBifrost)
+		authenticator = new(eirinifakes.FakeAuthenticator)
+		authenticator.AuthenticateReturns(true)
+		logger = lagertest.NewTestLogger("port-forward-test")
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(New(bifrost, new(eirinifakes.FakeStager), authenticator, logger))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the client opens a data and error stream pair for a port", func() {
+		It("forwards the connection to the bifrost once both streams have arrived", func() {
+			conn, protocol, err := dialPortForward(server, "/apps/guid/version/portforward/1")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			Expect(protocol).To(Equal(portForwardProtocol))
+
+			_, err = createPortForwardStream(conn, "data", "8080", "req-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createPortForwardStream(conn, "error", "8080", "req-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(bifrost.PortForwardCallCount).Should(Equal(1))
+			_, identifier, index, port, _ := bifrost.PortForwardArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid"))
+			Expect(identifier.Version).To(Equal("version"))
+			Expect(index).To(Equal(uint(1)))
+			Expect(port).To(Equal(int32(8080)))
+		})
+	})
+
+	Context("when unauthenticated", func() {
+		BeforeEach(func() {
+			authenticator.AuthenticateReturns(false)
+		})
+
+		It("rejects the request before attempting the SPDY handshake", func() {
+			resp, err := http.Post(server.URL+"/apps/guid/version/portforward/1", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(bifrost.PortForwardCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -1,19 +1,175 @@
 package event
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
 	"code.cloudfoundry.org/eirini/events"
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/util"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/runtimeschema/cc_messages"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
-type DefaultCrashReportGenerator struct{}
+// logTailLines bounds how much of a crashed container's log is pulled into
+// a CrashReport. Kept small since it is included in every crash payload.
+const logTailLines = 20
+
+// Waiting-state reasons Generate recognizes as a crash. CrashLoopBackOff is
+// a container that ran and keeps failing; the other four never got to run
+// at all, so a crashed container of that kind has no LastTerminationState.
+const (
+	CrashLoopBackOff           = "CrashLoopBackOff"
+	ImagePullBackOff           = "ImagePullBackOff"
+	ErrImagePull               = "ErrImagePull"
+	CreateContainerConfigError = "CreateContainerConfigError"
+	RunContainerError          = "RunContainerError"
+)
+
+// OOMKilled is the terminated-state reason reported for a container killed
+// by the kernel OOM killer; oomKilledExitCode is the exit code it leaves
+// behind on container runtimes that don't set Reason.
+const (
+	OOMKilled         = "OOMKilled"
+	oomKilledExitCode = 137
+)
+
+var crashingWaitingReasons = map[string]bool{
+	CrashLoopBackOff:           true,
+	ImagePullBackOff:           true,
+	ErrImagePull:               true,
+	CreateContainerConfigError: true,
+	RunContainerError:          true,
+}
+
+// containerTypeInit tags a CrashReport generated from a pod's init
+// container rather than its app container.
+const containerTypeInit = "init"
+
+// recordMaxAge bounds how long DefaultCrashReportGenerator remembers a pod
+// it hasn't seen again, so a long-running process doesn't accumulate one
+// podRecord per pod it has ever observed.
+const recordMaxAge = 10 * time.Minute
+
+// podRecord is a PLEG-style snapshot pair for one pod, keyed by pod UID:
+// current is the most recently observed state, old is the state before
+// that. Generate diffs the two to tell a genuine state transition (a
+// container dying or restarting) from the informer simply re-delivering a
+// pod it has already reported on.
+type podRecord struct {
+	old      *v1.Pod
+	current  *v1.Pod
+	lastSeen time.Time
+}
+
+// DefaultCrashReportGenerator is a Generator that only emits a CrashReport
+// on a container state transition (ContainerDied, ContainerRestarted),
+// modeled on kubelet's Generic PLEG. Its zero value is ready to use.
+type DefaultCrashReportGenerator struct {
+	mutex   sync.Mutex
+	records map[types.UID]*podRecord
+}
+
+// Generate looks for a crashed init container first, since a pod stuck on a
+// failing init (e.g. a buildpack or credential-fetching sidecar) never gets
+// to start its app container and would otherwise never produce a report,
+// then falls back to the app container. It returns false without
+// inspecting container state at all when pod hasn't transitioned since the
+// last time it was observed.
+func (g *DefaultCrashReportGenerator) Generate(pod *v1.Pod, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool) {
+	if !g.recordTransition(pod, time.Now()) {
+		return events.CrashReport{}, false
+	}
+
+	if report, ok := generateContainerCrashReport(pod, pod.Status.InitContainerStatuses, containerTypeInit, clientset, logger); ok {
+		return report, true
+	}
+
+	return generateContainerCrashReport(pod, pod.Status.ContainerStatuses, "", clientset, logger)
+}
+
+// recordTransition updates g's record for pod and reports whether a
+// container on pod transitioned (died or restarted) since the previous
+// observation. The first observation of a pod is always treated as a
+// transition, since there is no prior state to compare against.
+func (g *DefaultCrashReportGenerator) recordTransition(pod *v1.Pod, now time.Time) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.records == nil {
+		g.records = make(map[types.UID]*podRecord)
+	}
+	g.evictStaleLocked(now)
+
+	record, ok := g.records[pod.UID]
+	if !ok {
+		g.records[pod.UID] = &podRecord{current: pod, lastSeen: now}
+		return true
+	}
+
+	transitioned := podTransitioned(record.current, pod)
+	record.old = record.current
+	record.current = pod
+	record.lastSeen = now
+
+	return transitioned
+}
+
+// evictStaleLocked drops records for pods not observed within recordMaxAge.
+// Callers must hold g.mutex.
+func (g *DefaultCrashReportGenerator) evictStaleLocked(now time.Time) {
+	for uid, record := range g.records {
+		if now.Sub(record.lastSeen) > recordMaxAge {
+			delete(g.records, uid)
+		}
+	}
+}
+
+// podTransitioned reports whether any container or init container died
+// (newly Terminated) or restarted (RestartCount increased) between old and
+// current.
+func podTransitioned(old, current *v1.Pod) bool {
+	return statusesTransitioned(old.Status.InitContainerStatuses, current.Status.InitContainerStatuses) ||
+		statusesTransitioned(old.Status.ContainerStatuses, current.Status.ContainerStatuses)
+}
+
+func statusesTransitioned(old, current []v1.ContainerStatus) bool {
+	oldByName := make(map[string]v1.ContainerStatus, len(old))
+	for _, status := range old {
+		oldByName[status.Name] = status
+	}
+
+	for _, status := range current {
+		previous, ok := oldByName[status.Name]
+		if !ok || containerTransitioned(previous, status) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containerTransitioned reports whether current represents a
+// ContainerRestarted (RestartCount increased) or ContainerDied (newly
+// Terminated) transition relative to old.
+func containerTransitioned(old, current v1.ContainerStatus) bool {
+	if current.RestartCount > old.RestartCount {
+		return true
+	}
+
+	return current.State.Terminated != nil && old.State.Terminated == nil
+}
 
-func (DefaultCrashReportGenerator) Generate(pod *v1.Pod, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool) {
-	statuses := pod.Status.ContainerStatuses
+// generateContainerCrashReport produces a CrashReport from whichever of
+// statuses has crashed or terminated, tagging the report with containerType
+// ("" for an app container, containerTypeInit for an init container).
+func generateContainerCrashReport(pod *v1.Pod, statuses []v1.ContainerStatus, containerType string, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool) {
 	if len(statuses) == 0 {
 		return events.CrashReport{}, false
 	}
@@ -24,20 +180,46 @@ func (DefaultCrashReportGenerator) Generate(pod *v1.Pod, clientset kubernetes.In
 		return events.CrashReport{}, false
 	}
 
-	if status := getTerminatedContainerStatusIfAny(pod.Status.ContainerStatuses); status != nil {
-		return generateReportForTerminatedPod(pod, status, clientset, logger)
+	if status := getTerminatedContainerStatusIfAny(statuses); status != nil {
+		return generateReportForTerminatedPod(pod, status, containerType, clientset, logger)
 	}
 
-	if container := getCrashedContainerStatusIfAny(pod.Status.ContainerStatuses); container != nil {
-		exitStatus := int(container.LastTerminationState.Terminated.ExitCode)
-		exitDescription := container.LastTerminationState.Terminated.Reason
-		crashTimestamp := int64(container.LastTerminationState.Terminated.StartedAt.Second())
-		return generateReport(pod, container.State.Waiting.Reason, exitStatus, exitDescription, crashTimestamp, int(container.RestartCount))
+	if container := getCrashedContainerStatusIfAny(statuses); container != nil {
+		return generateReportForCrashedContainer(pod, container, containerType, clientset, logger)
 	}
 	return events.CrashReport{}, false
 }
 
-func generateReportForTerminatedPod(pod *v1.Pod, status *v1.ContainerStatus, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool) {
+func generateReportForCrashedContainer(pod *v1.Pod, container *v1.ContainerStatus, containerType string, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool) {
+	reason := container.State.Waiting.Reason
+
+	exitStatus := 0
+	exitDescription := reason
+	var crashTimestamp int64
+	var lastMessage string
+
+	if terminated := container.LastTerminationState.Terminated; terminated != nil {
+		exitStatus = int(terminated.ExitCode)
+		exitDescription = terminated.Reason
+		crashTimestamp = terminated.StartedAt.Unix()
+		lastMessage = terminated.Message
+	}
+
+	podEvents := getPodEvents(pod, clientset, logger)
+	exitDescription = normalizeExitDescription(reason, exitStatus, exitDescription, podEvents)
+
+	report, ok := generateReport(pod, reason, exitStatus, exitDescription, crashTimestamp, int(container.RestartCount))
+	if ok {
+		report.ContainerType = containerType
+		report.ContainerName = container.Name
+		report.LastTerminationMessage = lastMessage
+		report.LogTail = fetchLogTail(clientset, pod, container.Name, int(container.RestartCount), logger)
+		attachCrashDiagnostic(&report, pod, podEvents)
+	}
+	return report, ok
+}
+
+func generateReportForTerminatedPod(pod *v1.Pod, status *v1.ContainerStatus, containerType string, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool) {
 	podEvents, err := k8s.GetEvents(clientset.CoreV1().Events(pod.Namespace), *pod)
 	if err != nil {
 		logger.Error("failed-to-get-k8s-events", err, lager.Data{"guid": pod.Annotations[k8s.AnnotationProcessGUID]})
@@ -48,8 +230,90 @@ func generateReportForTerminatedPod(pod *v1.Pod, status *v1.ContainerStatus, cli
 	}
 
 	terminated := status.State.Terminated
+	exitDescription := normalizeExitDescription(terminated.Reason, int(terminated.ExitCode), terminated.Reason, podEvents)
+
+	report, ok := generateReport(pod, terminated.Reason, int(terminated.ExitCode), exitDescription, terminated.StartedAt.Unix(), int(status.RestartCount))
+	if ok {
+		report.ContainerType = containerType
+		report.ContainerName = status.Name
+		report.LastTerminationMessage = terminated.Message
+		report.LogTail = fetchLogTail(clientset, pod, status.Name, int(status.RestartCount), logger)
+		attachCrashDiagnostic(&report, pod, podEvents)
+	}
+	return report, ok
+}
+
+// getPodEvents best-effort fetches pod's events, logging and returning nil
+// on failure rather than suppressing the crash report: unlike the
+// terminated-pod path, a waiting-container crash is still worth reporting
+// even without event correlation.
+func getPodEvents(pod *v1.Pod, clientset kubernetes.Interface, logger lager.Logger) []v1.Event {
+	podEvents, err := k8s.GetEvents(clientset.CoreV1().Events(pod.Namespace), *pod)
+	if err != nil {
+		logger.Error("failed-to-get-k8s-events", err, lager.Data{"guid": pod.Annotations[k8s.AnnotationProcessGUID]})
+		return nil
+	}
+	return podEvents
+}
+
+// normalizeExitDescription maps a handful of well-known crash reasons to a
+// human-readable ExitDescription; anything else falls back to fallback
+// unchanged (typically the raw terminated Reason).
+func normalizeExitDescription(reason string, exitCode int, fallback string, podEvents []v1.Event) string {
+	switch {
+	case reason == OOMKilled || exitCode == oomKilledExitCode:
+		return "out of memory"
+	case reason == ImagePullBackOff || reason == ErrImagePull:
+		return fmt.Sprintf("image pull failed: %s", imagePullFailureMessage(podEvents))
+	default:
+		return fallback
+	}
+}
+
+// imagePullFailureMessage pulls the human-readable message off the pod's
+// FailedPulling/Failed event, since the container status itself carries no
+// more detail than "ImagePullBackOff"/"ErrImagePull".
+func imagePullFailureMessage(podEvents []v1.Event) string {
+	for _, podEvent := range podEvents {
+		if podEvent.Reason == "FailedPulling" || podEvent.Reason == "Failed" {
+			return podEvent.Message
+		}
+	}
+
+	return "unknown"
+}
+
+// attachCrashDiagnostic records the Reason/Message/source component of
+// pod's most recent Warning event on report, so a consumer of the crash
+// stream can see e.g. the underlying "BackOff"/"FailedMount"/"Unhealthy"
+// detail without a separate API round trip.
+func attachCrashDiagnostic(report *events.CrashReport, pod *v1.Pod, podEvents []v1.Event) {
+	latest := latestWarningEvent(pod, podEvents)
+	if latest == nil {
+		return
+	}
+
+	report.CrashDiagnostic = fmt.Sprintf("%s: %s", latest.Reason, latest.Message)
+	report.CrashDiagnosticSource = latest.Source.Component
+}
+
+// latestWarningEvent returns the most recent (by LastTimestamp) Warning
+// event whose InvolvedObject is pod, or nil if there is none.
+func latestWarningEvent(pod *v1.Pod, podEvents []v1.Event) *v1.Event {
+	var latest *v1.Event
+
+	for i := range podEvents {
+		candidate := &podEvents[i]
+		if candidate.InvolvedObject.UID != pod.UID || candidate.Type != v1.EventTypeWarning {
+			continue
+		}
 
-	return generateReport(pod, terminated.Reason, int(terminated.ExitCode), terminated.Reason, int64(terminated.StartedAt.Second()), int(status.RestartCount))
+		if latest == nil || candidate.LastTimestamp.After(latest.LastTimestamp.Time) {
+			latest = candidate
+		}
+	}
+
+	return latest
 }
 
 func generateReport(
@@ -63,7 +327,9 @@ func generateReport(
 	index, _ := util.ParseAppIndex(pod.Name)
 
 	return events.CrashReport{
-		ProcessGUID: pod.Annotations[k8s.AnnotationProcessGUID],
+		ProcessGUID:  pod.Annotations[k8s.AnnotationProcessGUID],
+		PodNamespace: pod.Namespace,
+		PodUID:       pod.UID,
 		AppCrashedRequest: cc_messages.AppCrashedRequest{
 			Reason:          reason,
 			Instance:        pod.Name,
@@ -76,6 +342,37 @@ func generateReport(
 	}, true
 }
 
+// fetchLogTail best-effort pulls the last few lines of a crashed container's
+// log stream so operators don't need a separate `kubectl logs` round trip to
+// see what happened. Failures are logged and otherwise ignored: a missing
+// log tail should never suppress a crash report. Previous is only requested
+// when restartCount is positive: a container crashing on its first attempt
+// has no prior instantiation to fetch logs from, and the current instance's
+// logs (the default, non-Previous stream) are the crash logs.
+func fetchLogTail(clientset kubernetes.Interface, pod *v1.Pod, containerName string, restartCount int, logger lager.Logger) []string {
+	tailLines := int64(logTailLines)
+	req := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: containerName,
+		Previous:  restartCount > 0,
+		TailLines: &tailLines,
+	})
+
+	stream, err := req.Stream(context.Background())
+	if err != nil {
+		logger.Error("failed-to-stream-pod-logs", err, lager.Data{"guid": pod.Annotations[k8s.AnnotationProcessGUID], "container": containerName})
+		return nil
+	}
+	defer stream.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
+
 func getTerminatedContainerStatusIfAny(statuses []v1.ContainerStatus) *v1.ContainerStatus {
 	for _, status := range statuses {
 		terminated := status.State.Terminated
@@ -90,7 +387,7 @@ func getTerminatedContainerStatusIfAny(statuses []v1.ContainerStatus) *v1.Contai
 func getCrashedContainerStatusIfAny(statuses []v1.ContainerStatus) *v1.ContainerStatus {
 	for _, status := range statuses {
 		waiting := status.State.Waiting
-		if waiting != nil && waiting.Reason == CrashLoopBackOff {
+		if waiting != nil && crashingWaitingReasons[waiting.Reason] {
 			return &status
 		}
 	}
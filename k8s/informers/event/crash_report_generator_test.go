@@ -46,6 +46,7 @@ var _ = Describe("CrashReportGenerator", func() {
 				Expect(returned).To(BeTrue())
 				Expect(report).To(Equal(events.CrashReport{
 					ProcessGUID: "test-pod-anno",
+					PodUID:      "test-pod-uid",
 					AppCrashedRequest: cc_messages.AppCrashedRequest{
 						Reason:          event.CrashLoopBackOff,
 						Instance:        "test-pod-0",
@@ -53,7 +54,7 @@ var _ = Describe("CrashReportGenerator", func() {
 						ExitStatus:      1,
 						ExitDescription: "better luck next time",
 						CrashCount:      3,
-						CrashTimestamp:  int64(crashTime.Time.Second()),
+						CrashTimestamp:  crashTime.Time.Unix(),
 					},
 				}))
 			})
@@ -70,6 +71,7 @@ var _ = Describe("CrashReportGenerator", func() {
 				Expect(returned).To(BeTrue())
 				Expect(report).To(Equal(events.CrashReport{
 					ProcessGUID: "test-pod-anno",
+					PodUID:      "test-pod-uid",
 					AppCrashedRequest: cc_messages.AppCrashedRequest{
 						Reason:          event.CrashLoopBackOff,
 						Instance:        "test-pod-0",
@@ -77,13 +79,182 @@ var _ = Describe("CrashReportGenerator", func() {
 						ExitStatus:      1,
 						ExitDescription: "better luck next time",
 						CrashCount:      3,
-						CrashTimestamp:  int64(crashTime.Time.Second()),
+						CrashTimestamp:  crashTime.Time.Unix(),
 					},
 				}))
 			})
 		})
 	})
 
+	Context("When an init container is in CrashLoopBackOff", func() {
+		Context("When the app container is running alongside a failed init container", func() {
+			BeforeEach(func() {
+				pod = newInitContainerCrashedPod()
+			})
+
+			It("should return a crashed report tagged as an init-container crash", func() {
+				generator := event.DefaultCrashReportGenerator{}
+				report, returned := generator.Generate(pod, client, logger)
+				Expect(returned).To(BeTrue())
+				Expect(report).To(Equal(events.CrashReport{
+					ProcessGUID:   "test-pod-anno",
+					PodUID:        "test-pod-uid",
+					ContainerType: "init",
+					ContainerName: "credential-fetcher",
+					AppCrashedRequest: cc_messages.AppCrashedRequest{
+						Reason:          event.CrashLoopBackOff,
+						Instance:        "test-pod-0",
+						Index:           0,
+						ExitStatus:      1,
+						ExitDescription: "could not fetch credentials",
+						CrashCount:      3,
+						CrashTimestamp:  crashTime.Time.Unix(),
+					},
+				}))
+			})
+		})
+	})
+
+	Context("When an init container has terminated", func() {
+		Context("When the app container is running alongside a terminated init container", func() {
+			BeforeEach(func() {
+				pod = newInitContainerTerminatedPod()
+			})
+
+			It("should return a crashed report tagged as an init-container crash", func() {
+				generator := event.DefaultCrashReportGenerator{}
+				report, returned := generator.Generate(pod, client, logger)
+				Expect(returned).To(BeTrue())
+				Expect(report).To(Equal(events.CrashReport{
+					ProcessGUID:   "test-pod-anno",
+					PodUID:        "test-pod-uid",
+					ContainerType: "init",
+					ContainerName: "credential-fetcher",
+					AppCrashedRequest: cc_messages.AppCrashedRequest{
+						Reason:          "could not fetch credentials",
+						Instance:        "test-pod-0",
+						Index:           0,
+						ExitStatus:      1,
+						ExitDescription: "could not fetch credentials",
+						CrashCount:      4,
+						CrashTimestamp:  crashTime.Time.Unix(),
+					},
+				}))
+			})
+		})
+	})
+
+	Context("When a container has been OOM killed", func() {
+		Context("reported via Reason", func() {
+			BeforeEach(func() {
+				pod = newOOMKilledTerminatedPod()
+			})
+
+			It("normalizes the exit description", func() {
+				generator := event.DefaultCrashReportGenerator{}
+				report, returned := generator.Generate(pod, client, logger)
+				Expect(returned).To(BeTrue())
+				Expect(report.AppCrashedRequest.Reason).To(Equal(event.OOMKilled))
+				Expect(report.AppCrashedRequest.ExitDescription).To(Equal("out of memory"))
+			})
+		})
+
+		Context("reported via exit code 137 with an unrelated Reason", func() {
+			BeforeEach(func() {
+				pod = newOOMKilledByExitCodePod()
+			})
+
+			It("still normalizes the exit description", func() {
+				generator := event.DefaultCrashReportGenerator{}
+				report, returned := generator.Generate(pod, client, logger)
+				Expect(returned).To(BeTrue())
+				Expect(report.AppCrashedRequest.ExitDescription).To(Equal("out of memory"))
+			})
+		})
+	})
+
+	Context("When a container can't pull its image", func() {
+		BeforeEach(func() {
+			pod = newImagePullBackOffPod()
+
+			podEvent := v1.Event{
+				InvolvedObject: v1.ObjectReference{Namespace: pod.Namespace, Name: pod.Name},
+				Reason:         "FailedPulling",
+				Message:        "rpc error: image not found",
+			}
+			_, err := client.CoreV1().Events(pod.Namespace).Create(&podEvent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("includes the FailedPulling event message in the exit description", func() {
+			generator := event.DefaultCrashReportGenerator{}
+			report, returned := generator.Generate(pod, client, logger)
+			Expect(returned).To(BeTrue())
+			Expect(report.AppCrashedRequest.Reason).To(Equal(event.ImagePullBackOff))
+			Expect(report.AppCrashedRequest.ExitDescription).To(Equal("image pull failed: rpc error: image not found"))
+		})
+	})
+
+	Context("When a container fails with a config or runtime error", func() {
+		BeforeEach(func() {
+			pod = newCreateContainerConfigErrorPod()
+		})
+
+		It("falls back to the reason itself as the exit description", func() {
+			generator := event.DefaultCrashReportGenerator{}
+			report, returned := generator.Generate(pod, client, logger)
+			Expect(returned).To(BeTrue())
+			Expect(report.AppCrashedRequest.Reason).To(Equal(event.CreateContainerConfigError))
+			Expect(report.AppCrashedRequest.ExitDescription).To(Equal(event.CreateContainerConfigError))
+		})
+	})
+
+	Context("When there are Warning events correlated with the crash", func() {
+		BeforeEach(func() {
+			pod = newCrashedPod()
+
+			olderEvent := v1.Event{
+				ObjectMeta:     meta.ObjectMeta{Name: "older-event"},
+				InvolvedObject: v1.ObjectReference{UID: pod.UID},
+				Type:           v1.EventTypeWarning,
+				Reason:         "FailedMount",
+				Message:        "unable to mount volume",
+				Source:         v1.EventSource{Component: "kubelet"},
+				LastTimestamp:  meta.Time{Time: crashTime.Time.Add(-time.Minute)},
+			}
+			newerEvent := v1.Event{
+				ObjectMeta:     meta.ObjectMeta{Name: "newer-event"},
+				InvolvedObject: v1.ObjectReference{UID: pod.UID},
+				Type:           v1.EventTypeWarning,
+				Reason:         "Unhealthy",
+				Message:        "readiness probe failed",
+				Source:         v1.EventSource{Component: "kubelet"},
+				LastTimestamp:  crashTime,
+			}
+			normalEvent := v1.Event{
+				ObjectMeta:     meta.ObjectMeta{Name: "normal-event"},
+				InvolvedObject: v1.ObjectReference{UID: pod.UID},
+				Type:           v1.EventTypeNormal,
+				Reason:         "Scheduled",
+				Message:        "Successfully assigned",
+				LastTimestamp:  crashTime,
+			}
+
+			for _, podEvent := range []v1.Event{olderEvent, newerEvent, normalEvent} {
+				_, err := client.CoreV1().Events(pod.Namespace).Create(&podEvent)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("surfaces the most recent Warning event as the crash diagnostic", func() {
+			generator := event.DefaultCrashReportGenerator{}
+			report, returned := generator.Generate(pod, client, logger)
+			Expect(returned).To(BeTrue())
+			Expect(report.CrashDiagnostic).To(Equal("Unhealthy: readiness probe failed"))
+			Expect(report.CrashDiagnosticSource).To(Equal("kubelet"))
+		})
+	})
+
 	Context("When app has been terminated", func() {
 		Context("When there is one container in the pod", func() {
 			BeforeEach(func() {
@@ -96,6 +267,7 @@ var _ = Describe("CrashReportGenerator", func() {
 				Expect(returned).To(BeTrue())
 				Expect(report).To(Equal(events.CrashReport{
 					ProcessGUID: "test-pod-anno",
+					PodUID:      "test-pod-uid",
 					AppCrashedRequest: cc_messages.AppCrashedRequest{
 						Reason:          "better luck next time",
 						Instance:        "test-pod-0",
@@ -103,7 +275,7 @@ var _ = Describe("CrashReportGenerator", func() {
 						ExitStatus:      1,
 						ExitDescription: "better luck next time",
 						CrashCount:      8,
-						CrashTimestamp:  int64(crashTime.Time.Second()),
+						CrashTimestamp:  crashTime.Time.Unix(),
 					},
 				}))
 			})
@@ -169,6 +341,29 @@ var _ = Describe("CrashReportGenerator", func() {
 				})
 			})
 
+			Context("When there is a Warning event correlated with the crash", func() {
+				BeforeEach(func() {
+					podEvent := v1.Event{
+						InvolvedObject: v1.ObjectReference{UID: pod.UID},
+						Type:           v1.EventTypeWarning,
+						Reason:         "BackOff",
+						Message:        "back-off restarting failed container",
+						Source:         v1.EventSource{Component: "kubelet"},
+						LastTimestamp:  crashTime,
+					}
+					_, err := client.CoreV1().Events(pod.Namespace).Create(&podEvent)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("attaches the event's reason and message as the crash diagnostic", func() {
+					generator := event.DefaultCrashReportGenerator{}
+					report, returned := generator.Generate(pod, client, logger)
+					Expect(returned).To(BeTrue())
+					Expect(report.CrashDiagnostic).To(Equal("BackOff: back-off restarting failed container"))
+					Expect(report.CrashDiagnosticSource).To(Equal("kubelet"))
+				})
+			})
+
 			Context("When getting events fails", func() {
 				BeforeEach(func() {
 					reaction := func(action testcore.Action) (handled bool, ret runtime.Object, err error) {
@@ -205,6 +400,7 @@ var _ = Describe("CrashReportGenerator", func() {
 				Expect(returned).To(BeTrue())
 				Expect(report).To(Equal(events.CrashReport{
 					ProcessGUID: "test-pod-anno",
+					PodUID:      "test-pod-uid",
 					AppCrashedRequest: cc_messages.AppCrashedRequest{
 						Reason:          "better luck next time",
 						Instance:        "test-pod-0",
@@ -212,7 +408,7 @@ var _ = Describe("CrashReportGenerator", func() {
 						ExitStatus:      1,
 						ExitDescription: "better luck next time",
 						CrashCount:      8,
-						CrashTimestamp:  int64(crashTime.Time.Second()),
+						CrashTimestamp:  crashTime.Time.Unix(),
 					},
 				}))
 			})
@@ -265,6 +461,36 @@ var _ = Describe("CrashReportGenerator", func() {
 		})
 	})
 
+	Context("When the same generator observes a crashed pod more than once", func() {
+		var generator *event.DefaultCrashReportGenerator
+
+		BeforeEach(func() {
+			generator = &event.DefaultCrashReportGenerator{}
+			pod = newCrashedPod()
+		})
+
+		It("suppresses the duplicate and only emits one report", func() {
+			_, returned := generator.Generate(pod, client, logger)
+			Expect(returned).To(BeTrue())
+
+			_, returned = generator.Generate(pod, client, logger)
+			Expect(returned).To(BeFalse())
+		})
+
+		It("emits a second report once the container restarts again", func() {
+			report, returned := generator.Generate(pod, client, logger)
+			Expect(returned).To(BeTrue())
+			Expect(report.AppCrashedRequest.CrashCount).To(Equal(3))
+
+			restarted := newCrashedPod()
+			restarted.Status.ContainerStatuses[0].RestartCount = 4
+
+			report, returned = generator.Generate(restarted, client, logger)
+			Expect(returned).To(BeTrue())
+			Expect(report.AppCrashedRequest.CrashCount).To(Equal(4))
+		})
+	})
+
 })
 
 func newTerminatedPod() *v1.Pod {
@@ -349,11 +575,123 @@ func newMultiContainerCrashedPod() *v1.Pod {
 	})
 }
 
+func newOOMKilledTerminatedPod() *v1.Pod {
+	return newPod([]v1.ContainerStatus{
+		{
+			RestartCount: 2,
+			State: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					Reason:    event.OOMKilled,
+					StartedAt: crashTime,
+					ExitCode:  137,
+				},
+			},
+		},
+	})
+}
+
+func newOOMKilledByExitCodePod() *v1.Pod {
+	return newPod([]v1.ContainerStatus{
+		{
+			RestartCount: 2,
+			State: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					Reason:    "Error",
+					StartedAt: crashTime,
+					ExitCode:  137,
+				},
+			},
+		},
+	})
+}
+
+func newImagePullBackOffPod() *v1.Pod {
+	return newPod([]v1.ContainerStatus{
+		{
+			RestartCount: 0,
+			State: v1.ContainerState{
+				Waiting: &v1.ContainerStateWaiting{
+					Reason: event.ImagePullBackOff,
+				},
+			},
+		},
+	})
+}
+
+func newCreateContainerConfigErrorPod() *v1.Pod {
+	return newPod([]v1.ContainerStatus{
+		{
+			RestartCount: 0,
+			State: v1.ContainerState{
+				Waiting: &v1.ContainerStateWaiting{
+					Reason: event.CreateContainerConfigError,
+				},
+			},
+		},
+	})
+}
+
+func newInitContainerCrashedPod() *v1.Pod {
+	pod := newPod([]v1.ContainerStatus{
+		{
+			RestartCount: 1,
+			State: v1.ContainerState{
+				Running: &v1.ContainerStateRunning{},
+			},
+		},
+	})
+	pod.Status.InitContainerStatuses = []v1.ContainerStatus{
+		{
+			Name:         "credential-fetcher",
+			RestartCount: 3,
+			State: v1.ContainerState{
+				Waiting: &v1.ContainerStateWaiting{
+					Reason: event.CrashLoopBackOff,
+				},
+			},
+			LastTerminationState: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					ExitCode:  1,
+					Reason:    "could not fetch credentials",
+					StartedAt: crashTime,
+				},
+			},
+		},
+	}
+	return pod
+}
+
+func newInitContainerTerminatedPod() *v1.Pod {
+	pod := newPod([]v1.ContainerStatus{
+		{
+			RestartCount: 1,
+			State: v1.ContainerState{
+				Running: &v1.ContainerStateRunning{},
+			},
+		},
+	})
+	pod.Status.InitContainerStatuses = []v1.ContainerStatus{
+		{
+			Name:         "credential-fetcher",
+			RestartCount: 4,
+			State: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					Reason:    "could not fetch credentials",
+					StartedAt: crashTime,
+					ExitCode:  1,
+				},
+			},
+		},
+	}
+	return pod
+}
+
 func newPod(statuses []v1.ContainerStatus) *v1.Pod {
 	name := "test-pod"
 	return &v1.Pod{
 		ObjectMeta: meta.ObjectMeta{
 			Name: fmt.Sprintf("%s-%d", name, 0),
+			UID:  "test-pod-uid",
 			Annotations: map[string]string{
 				k8s.AnnotationProcessGUID: fmt.Sprintf("%s-anno", name),
 			},
@@ -0,0 +1,60 @@
+package event
+
+import (
+	"container/list"
+	"sync"
+)
+
+// crashKey identifies a single crash occurrence. Several informers can
+// observe the same pod transition, so reports are deduplicated on the
+// combination of pod, container and restart count rather than relying on
+// a single watcher being authoritative.
+type crashKey struct {
+	podUID        string
+	containerName string
+	restartCount  int32
+}
+
+// dedupeCache is a small bounded LRU used to suppress duplicate crash
+// reports for a pod/container/restartCount combination that has already
+// been reported. It is safe for concurrent use.
+type dedupeCache struct {
+	maxEntries int
+
+	mutex   sync.Mutex
+	entries map[crashKey]*list.Element
+	order   *list.List
+}
+
+func newDedupeCache(maxEntries int) *dedupeCache {
+	return &dedupeCache{
+		maxEntries: maxEntries,
+		entries:    make(map[crashKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// seen reports whether this crash has already been recorded, and records it
+// if not.
+func (c *dedupeCache) seen(key crashKey) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return true
+	}
+
+	elem := c.order.PushFront(key)
+	c.entries[key] = elem
+
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(crashKey))
+		}
+	}
+
+	return false
+}
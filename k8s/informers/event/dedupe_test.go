@@ -0,0 +1,43 @@
+package event
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("dedupeCache", func() {
+	var cache *dedupeCache
+
+	BeforeEach(func() {
+		cache = newDedupeCache(2)
+	})
+
+	It("reports a key as unseen the first time it is observed", func() {
+		Expect(cache.seen(crashKey{podUID: "pod-1", containerName: "app", restartCount: 1})).To(BeFalse())
+	})
+
+	It("reports the same key as seen on subsequent observations", func() {
+		key := crashKey{podUID: "pod-1", containerName: "app", restartCount: 1}
+		Expect(cache.seen(key)).To(BeFalse())
+		Expect(cache.seen(key)).To(BeTrue())
+	})
+
+	It("treats a different restart count as a distinct crash", func() {
+		first := crashKey{podUID: "pod-1", containerName: "app", restartCount: 1}
+		second := crashKey{podUID: "pod-1", containerName: "app", restartCount: 2}
+		Expect(cache.seen(first)).To(BeFalse())
+		Expect(cache.seen(second)).To(BeFalse())
+	})
+
+	It("evicts the oldest entry once the cache is full", func() {
+		first := crashKey{podUID: "pod-1", containerName: "app", restartCount: 1}
+		second := crashKey{podUID: "pod-2", containerName: "app", restartCount: 1}
+		third := crashKey{podUID: "pod-3", containerName: "app", restartCount: 1}
+
+		Expect(cache.seen(first)).To(BeFalse())
+		Expect(cache.seen(second)).To(BeFalse())
+		Expect(cache.seen(third)).To(BeFalse())
+
+		Expect(cache.seen(first)).To(BeFalse(), "evicted entries should be reported as unseen again")
+	})
+})
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/lager"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// defaultDedupeCacheSize bounds the number of recently-seen crashes the
+// watcher remembers. A handful of minutes' worth of restarts across a large
+// namespace comfortably fits in a few thousand entries.
+const defaultDedupeCacheSize = 4096
+
+//go:generate counterfeiter . CrashReportPublisher
+
+// CrashReportPublisher delivers a generated CrashReport (e.g. onto NATS).
+type CrashReportPublisher interface {
+	Publish(events.CrashReport) error
+}
+
+// Generator produces a CrashReport from a pod's current state, or reports
+// that the pod has not crashed.
+type Generator interface {
+	Generate(pod *v1.Pod, clientset kubernetes.Interface, logger lager.Logger) (events.CrashReport, bool)
+}
+
+// PodCrashWatcher subscribes to Pod state transitions via a shared informer
+// instead of periodically polling the API server, generates a CrashReport
+// for pods that have crashed, and publishes it. It deduplicates reports for
+// the same (pod UID, container, restart count) so that re-syncs or multiple
+// informers observing the same pod don't produce duplicate notifications.
+type PodCrashWatcher struct {
+	Clientset kubernetes.Interface
+	Generator Generator
+	Publisher CrashReportPublisher
+	Logger    lager.Logger
+
+	dedupe *dedupeCache
+}
+
+// NewPodCrashWatcher constructs a PodCrashWatcher ready to Start.
+func NewPodCrashWatcher(clientset kubernetes.Interface, generator Generator, publisher CrashReportPublisher, logger lager.Logger) *PodCrashWatcher {
+	return &PodCrashWatcher{
+		Clientset: clientset,
+		Generator: generator,
+		Publisher: publisher,
+		Logger:    logger,
+		dedupe:    newDedupeCache(defaultDedupeCacheSize),
+	}
+}
+
+// Start begins watching Pod events across the cluster via a SharedInformer
+// and blocks until stopCh is closed.
+func (w *PodCrashWatcher) Start(resync time.Duration, stopCh <-chan struct{}) {
+	factory := informers.NewSharedInformerFactory(w.Clientset, resync)
+	podInformer := factory.Core().V1().Pods().Informer()
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.handle(obj) },
+		UpdateFunc: func(_, newObj interface{}) { w.handle(newObj) },
+	})
+
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	<-stopCh
+}
+
+func (w *PodCrashWatcher) handle(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	report, crashed := w.Generator.Generate(pod, w.Clientset, w.Logger)
+	if !crashed {
+		return
+	}
+
+	key := crashKey{
+		podUID:        string(pod.UID),
+		containerName: report.ContainerName,
+		restartCount:  int32(report.AppCrashedRequest.CrashCount),
+	}
+	if w.dedupe.seen(key) {
+		return
+	}
+
+	if err := w.Publisher.Publish(report); err != nil {
+		w.Logger.Error("failed-to-publish-crash-report", err, lager.Data{"guid": report.ProcessGUID})
+	}
+}
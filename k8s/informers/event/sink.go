@@ -0,0 +1,33 @@
+package event
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/events"
+)
+
+//go:generate counterfeiter . CrashReportSink
+
+// CrashReportSink delivers a single CrashReport to some downstream system,
+// e.g. Cloud Controller, loggregator, or a Kubernetes Event on the crashed
+// pod. Close flushes any reports a sink has buffered and releases its
+// resources; PodCrashWatcher and SinkPublisher never call it.
+type CrashReportSink interface {
+	Submit(ctx context.Context, report events.CrashReport) error
+	Close() error
+}
+
+// SinkPublisher adapts a CrashReportSink to the CrashReportPublisher
+// interface PodCrashWatcher expects, so a watcher can deliver through any
+// configured sink (and any batching/retry decorator wrapping it) without
+// PodCrashWatcher itself knowing about delivery mechanics.
+type SinkPublisher struct {
+	Sink CrashReportSink
+}
+
+// Publish submits report to the underlying sink with a background context,
+// since PodCrashWatcher's informer event handlers don't carry one of their
+// own.
+func (p SinkPublisher) Publish(report events.CrashReport) error {
+	return p.Sink.Submit(context.Background(), report)
+}
@@ -0,0 +1,164 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/eirini/retry"
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// crashReportsDroppedTotal counts crash reports discarded because a
+// batchingSink's in-memory queue was full when a new report arrived.
+var crashReportsDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "eirini_crash_reports_dropped_total",
+	Help: "Total number of crash reports dropped because the delivery queue was full.",
+})
+
+func init() {
+	prometheus.MustRegister(crashReportsDroppedTotal)
+}
+
+// BatchConfig configures batchingSink's flush triggers, retry backoff and
+// queue bound.
+type BatchConfig struct {
+	// Window and MaxSize flush the batch, whichever comes first. MaxSize
+	// <= 0 disables the size-based flush.
+	Window  time.Duration
+	MaxSize int
+
+	// QueueSize bounds how many reports batchingSink holds before it
+	// starts dropping the oldest to make room for new ones. QueueSize <=
+	// 0 disables the bound.
+	QueueSize int
+
+	Retry RetryConfig
+}
+
+// RetryConfig configures the exponential backoff a sink uses when delivery
+// fails.
+type RetryConfig = retry.Config
+
+// batchingSink buffers Submit calls and flushes them cfg.Window after the
+// first buffered report arrived, or once cfg.MaxSize reports are buffered,
+// whichever comes first, delivering them to backend one at a time with
+// exponential-backoff retry. Reports beyond
+// cfg.QueueSize are dropped oldest-first, so a stalled backend bounds
+// memory rather than growing without limit.
+type batchingSink struct {
+	backend CrashReportSink
+	cfg     BatchConfig
+	logger  lager.Logger
+
+	mutex   sync.Mutex
+	pending []events.CrashReport
+	timer   *time.Timer
+}
+
+// NewBatchingSink wraps backend so that Submit calls are coalesced and
+// retried per cfg.
+func NewBatchingSink(backend CrashReportSink, cfg BatchConfig, logger lager.Logger) CrashReportSink {
+	return &batchingSink{
+		backend: backend,
+		cfg:     cfg,
+		logger:  logger.Session("batching-sink"),
+	}
+}
+
+func (s *batchingSink) Submit(ctx context.Context, report events.CrashReport) error {
+	s.mutex.Lock()
+
+	s.pending = append(s.pending, report)
+	s.dropOldestLocked()
+
+	if s.cfg.MaxSize > 0 && len(s.pending) >= s.cfg.MaxSize {
+		pending := s.takePendingLocked()
+		s.mutex.Unlock()
+
+		return s.flushBatch(ctx, pending)
+	}
+
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.cfg.Window, s.flush)
+	}
+
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// dropOldestLocked discards the oldest buffered reports once pending
+// exceeds cfg.QueueSize. Callers must hold s.mutex.
+func (s *batchingSink) dropOldestLocked() {
+	if s.cfg.QueueSize <= 0 {
+		return
+	}
+
+	for len(s.pending) > s.cfg.QueueSize {
+		s.pending = s.pending[1:]
+		crashReportsDroppedTotal.Inc()
+	}
+}
+
+// flush is invoked by the batch window timer, so any backend error has
+// nowhere to return to and is logged instead.
+func (s *batchingSink) flush() {
+	s.mutex.Lock()
+	pending := s.takePendingLocked()
+	s.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if err := s.flushBatch(context.Background(), pending); err != nil {
+		s.logger.Error("failed-to-flush-batch", err, lager.Data{"reports": len(pending)})
+	}
+}
+
+// flushBatch submits each report in pending to backend individually,
+// retrying each per cfg.Retry, and returns the first error encountered.
+func (s *batchingSink) flushBatch(ctx context.Context, pending []events.CrashReport) error {
+	var firstErr error
+
+	for _, report := range pending {
+		err := retry.Do(s.cfg.Retry, func() error {
+			return s.backend.Submit(ctx, report)
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (s *batchingSink) takePendingLocked() []events.CrashReport {
+	pending := s.pending
+	s.pending = nil
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	return pending
+}
+
+// Close flushes any buffered reports before closing the backend.
+func (s *batchingSink) Close() error {
+	s.mutex.Lock()
+	pending := s.takePendingLocked()
+	s.mutex.Unlock()
+
+	if len(pending) > 0 {
+		if err := s.flushBatch(context.Background(), pending); err != nil {
+			return err
+		}
+	}
+
+	return s.backend.Close()
+}
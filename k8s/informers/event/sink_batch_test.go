@@ -0,0 +1,113 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/eirini/k8s/informers/event"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type spySink struct {
+	mutex     sync.Mutex
+	submitted [][]events.CrashReport
+	closed    bool
+	failNext  int
+}
+
+func (s *spySink) Submit(ctx context.Context, report events.CrashReport) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failNext > 0 {
+		s.failNext--
+		return errors.New("boom")
+	}
+
+	s.submitted = append(s.submitted, []events.CrashReport{report})
+	return nil
+}
+
+func (s *spySink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *spySink) submitCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.submitted)
+}
+
+var _ = Describe("batchingSink", func() {
+	var (
+		backend *spySink
+		sink    event.CrashReportSink
+		report  events.CrashReport
+	)
+
+	BeforeEach(func() {
+		backend = &spySink{}
+		report = events.CrashReport{ProcessGUID: "guid-a"}
+	})
+
+	It("flushes once MaxSize is reached, without waiting for the window", func() {
+		sink = event.NewBatchingSink(backend, event.BatchConfig{Window: time.Hour, MaxSize: 2}, lagertest.NewTestLogger("batch-test"))
+
+		Expect(sink.Submit(context.Background(), report)).To(Succeed())
+		Expect(backend.submitCount()).To(Equal(0))
+
+		Expect(sink.Submit(context.Background(), report)).To(Succeed())
+		Eventually(backend.submitCount).Should(Equal(2))
+	})
+
+	It("flushes once the window elapses, even below MaxSize", func() {
+		sink = event.NewBatchingSink(backend, event.BatchConfig{Window: 10 * time.Millisecond, MaxSize: 100}, lagertest.NewTestLogger("batch-test"))
+
+		Expect(sink.Submit(context.Background(), report)).To(Succeed())
+
+		Eventually(backend.submitCount).Should(Equal(1))
+	})
+
+	It("flushes any buffered reports and closes the backend on Close", func() {
+		sink = event.NewBatchingSink(backend, event.BatchConfig{Window: time.Hour, MaxSize: 100}, lagertest.NewTestLogger("batch-test"))
+
+		Expect(sink.Submit(context.Background(), report)).To(Succeed())
+		Expect(sink.Close()).To(Succeed())
+
+		Expect(backend.submitCount()).To(Equal(1))
+		Expect(backend.closed).To(BeTrue())
+	})
+
+	It("retries a failed delivery per the retry config", func() {
+		backend.failNext = 1
+		sink = event.NewBatchingSink(backend, event.BatchConfig{
+			Window:  time.Hour,
+			MaxSize: 1,
+			Retry:   event.RetryConfig{MaxAttempts: 2},
+		}, lagertest.NewTestLogger("batch-test"))
+
+		Expect(sink.Submit(context.Background(), report)).To(Succeed())
+		Eventually(backend.submitCount).Should(Equal(1))
+	})
+
+	It("drops the oldest buffered report once QueueSize is exceeded", func() {
+		sink = event.NewBatchingSink(backend, event.BatchConfig{Window: time.Hour, MaxSize: 100, QueueSize: 1}, lagertest.NewTestLogger("batch-test"))
+
+		first := events.CrashReport{ProcessGUID: "guid-first"}
+		second := events.CrashReport{ProcessGUID: "guid-second"}
+
+		Expect(sink.Submit(context.Background(), first)).To(Succeed())
+		Expect(sink.Submit(context.Background(), second)).To(Succeed())
+		Expect(sink.Close()).To(Succeed())
+
+		Expect(backend.submitted).To(HaveLen(1))
+		Expect(backend.submitted[0][0].ProcessGUID).To(Equal("guid-second"))
+	})
+})
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/lager"
+)
+
+// ccRequestTimeout bounds a single crash-report upload to Cloud Controller.
+const ccRequestTimeout = 10 * time.Second
+
+// ccSink posts a report's AppCrashedRequest to Cloud Controller's app-crashed
+// endpoint, the same payload shape CF has historically delivered crash
+// notifications in.
+type ccSink struct {
+	url    string
+	client *http.Client
+	logger lager.Logger
+}
+
+// NewCCSink constructs a CrashReportSink that uploads crash reports to the
+// Cloud Controller endpoint at url.
+func NewCCSink(url string, logger lager.Logger) CrashReportSink {
+	return &ccSink{
+		url:    url,
+		client: &http.Client{Timeout: ccRequestTimeout},
+		logger: logger.Session("cc-sink"),
+	}
+}
+
+func (s *ccSink) Submit(ctx context.Context, report events.CrashReport) error {
+	payload, err := json.Marshal(report.AppCrashedRequest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal app crashed request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build cc-uploader request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error("failed-to-post-crash-report", err, lager.Data{"guid": report.ProcessGUID})
+		return fmt.Errorf("failed to post crash report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("cc-uploader %q returned status %d for guid %q", s.url, resp.StatusCode, report.ProcessGUID)
+	}
+
+	return nil
+}
+
+func (s *ccSink) Close() error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/lager"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// eventSourceComponent tags every Event k8sEventSink writes so `kubectl
+// describe pod` and other consumers can tell an Eirini-authored crash
+// diagnostic apart from kubelet's own events.
+const eventSourceComponent = "eirini"
+
+// k8sEventSink writes a Warning-type Event on the crashed pod, so `kubectl
+// describe pod` shows the same crash reason Eirini reports to Cloud
+// Controller without a separate API round trip.
+type k8sEventSink struct {
+	clientset kubernetes.Interface
+	logger    lager.Logger
+}
+
+// NewKubernetesEventSink constructs a CrashReportSink that records crash
+// reports as Kubernetes Events against their originating pod.
+func NewKubernetesEventSink(clientset kubernetes.Interface, logger lager.Logger) CrashReportSink {
+	return &k8sEventSink{clientset: clientset, logger: logger.Session("k8s-event-sink")}
+}
+
+func (s *k8sEventSink) Submit(ctx context.Context, report events.CrashReport) error {
+	crashEvent := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "eirini-crash-",
+			Namespace:    report.PodNamespace,
+		},
+		InvolvedObject: v1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: report.PodNamespace,
+			Name:      report.AppCrashedRequest.Instance,
+			UID:       report.PodUID,
+		},
+		Type:    v1.EventTypeWarning,
+		Reason:  report.AppCrashedRequest.Reason,
+		Message: report.AppCrashedRequest.ExitDescription,
+		Source:  v1.EventSource{Component: eventSourceComponent},
+		Count:   1,
+	}
+
+	_, err := s.clientset.CoreV1().Events(report.PodNamespace).Create(ctx, crashEvent, metav1.CreateOptions{})
+	if err != nil {
+		s.logger.Error("failed-to-create-crash-event", err, lager.Data{"guid": report.ProcessGUID})
+		return fmt.Errorf("failed to create crash event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *k8sEventSink) Close() error {
+	return nil
+}
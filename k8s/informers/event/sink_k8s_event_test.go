@@ -0,0 +1,59 @@
+package event_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/eirini/k8s/informers/event"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/runtimeschema/cc_messages"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("k8sEventSink", func() {
+	var (
+		client *fake.Clientset
+		sink   event.CrashReportSink
+		report events.CrashReport
+	)
+
+	BeforeEach(func() {
+		client = fake.NewSimpleClientset()
+		sink = event.NewKubernetesEventSink(client, lagertest.NewTestLogger("k8s-event-sink-test"))
+
+		report = events.CrashReport{
+			ProcessGUID:  "test-pod-anno",
+			PodNamespace: "cf-workloads",
+			PodUID:       "test-pod-uid",
+			AppCrashedRequest: cc_messages.AppCrashedRequest{
+				Reason:          event.CrashLoopBackOff,
+				Instance:        "test-pod-0",
+				ExitDescription: "better luck next time",
+			},
+		}
+	})
+
+	It("writes a Warning event on the crashed pod", func() {
+		Expect(sink.Submit(context.Background(), report)).To(Succeed())
+
+		list, err := client.CoreV1().Events("cf-workloads").List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+
+		crashEvent := list.Items[0]
+		Expect(crashEvent.Type).To(Equal(v1.EventTypeWarning))
+		Expect(crashEvent.Reason).To(Equal(event.CrashLoopBackOff))
+		Expect(crashEvent.Message).To(Equal("better luck next time"))
+		Expect(crashEvent.InvolvedObject.Name).To(Equal("test-pod-0"))
+		Expect(crashEvent.InvolvedObject.UID).To(Equal(report.PodUID))
+		Expect(crashEvent.Source.Component).To(Equal("eirini"))
+	})
+
+	It("closes without error", func() {
+		Expect(sink.Close()).To(Succeed())
+	})
+})
@@ -0,0 +1,60 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/eirini/events"
+	"code.cloudfoundry.org/lager"
+	"github.com/nats-io/nats.go"
+)
+
+// crashSubject is the NATS subject crash reports are published onto for
+// loggregator-compatible consumers, mirroring the routing.* subject
+// convention route.Emitter uses for gorouter.
+const crashSubject = "loggregator.crash"
+
+// natsSink publishes crash reports as JSON onto crashSubject.
+type natsSink struct {
+	conn    *nats.Conn
+	subject string
+	logger  lager.Logger
+}
+
+// NewNATSSink connects to the NATS cluster at ip:port and returns a
+// CrashReportSink that publishes onto it.
+func NewNATSSink(ip string, port int, password string, logger lager.Logger) (CrashReportSink, error) {
+	url := fmt.Sprintf("nats://%s:%d", ip, port)
+
+	opts := []nats.Option{}
+	if password != "" {
+		opts = append(opts, nats.Token(password))
+	}
+
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	return &natsSink{conn: conn, subject: crashSubject, logger: logger.Session("nats-sink")}, nil
+}
+
+func (s *natsSink) Submit(ctx context.Context, report events.CrashReport) error {
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal crash report: %w", err)
+	}
+
+	if err := s.conn.Publish(s.subject, payload); err != nil {
+		s.logger.Error("failed-to-publish", err, lager.Data{"guid": report.ProcessGUID})
+		return fmt.Errorf("failed to publish crash report: %w", err)
+	}
+
+	return nil
+}
+
+func (s *natsSink) Close() error {
+	s.conn.Close()
+	return nil
+}
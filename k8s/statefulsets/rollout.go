@@ -0,0 +1,176 @@
+// Package statefulsets drives Kubernetes-native rolling updates of app
+// StatefulSets on behalf of the Bifrost. A rollout sets the partition field
+// of RollingUpdateStatefulSetStrategy to 0, so every instance picks up the
+// new pod template; the partition is not stepped to hold instances back.
+package statefulsets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// updateConflictsTotal counts how many times a rolling update had to
+// re-read and retry a StatefulSet write after losing a resourceVersion
+// race against another writer.
+var updateConflictsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "eirini_update_conflicts_total",
+	Help: "Total number of StatefulSet update conflicts retried during rolling updates.",
+})
+
+func init() {
+	prometheus.MustRegister(updateConflictsTotal)
+}
+
+// updateBackoff bounds how long Start spends retrying a conflicting
+// StatefulSet write: 5 attempts, jittered and growing exponentially so
+// retries from multiple callers don't lock-step against each other.
+var updateBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 100 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// RolloutManager performs rolling updates of a StatefulSet and
+// tracks their progress so it can be polled by rollout ID.
+type RolloutManager struct {
+	mutex    sync.RWMutex
+	rollouts map[string]*cf.RolloutStatus
+
+	// active maps a StatefulSet name to the most recently started rollout
+	// ID, so a pod watcher observing that StatefulSet's pods can resolve
+	// which rollout a transition belongs to without tracking rollout IDs
+	// itself.
+	active map[string]string
+}
+
+// NewRolloutManager constructs an empty RolloutManager.
+func NewRolloutManager() *RolloutManager {
+	return &RolloutManager{
+		rollouts: make(map[string]*cf.RolloutStatus),
+		active:   make(map[string]string),
+	}
+}
+
+// Start mutates the StatefulSet's pod template per the caller-supplied
+// mutate func, sets the rolling-update partition to 0 so every instance is
+// moved to the new pod template, and records a new in-memory RolloutStatus the caller can later poll with
+// Status.
+//
+// The get/mutate/update sequence is retried with updateBackoff on a 409
+// Conflict, re-reading the StatefulSet and re-applying mutate each time so a
+// concurrent writer's change is never silently clobbered.
+func (m *RolloutManager) Start(ctx context.Context, client v1.StatefulSetInterface, name string, mutate func(*appsv1.StatefulSet), logger lager.Logger) (string, error) {
+	var statefulSet *appsv1.StatefulSet
+	conflicts := 0
+
+	err := retry.RetryOnConflict(updateBackoff, func() error {
+		var err error
+		statefulSet, err = client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		mutate(statefulSet)
+
+		partition := int32(0)
+		if statefulSet.Spec.UpdateStrategy.RollingUpdate == nil {
+			statefulSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+		}
+		statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+
+		statefulSet, err = client.Update(ctx, statefulSet, metav1.UpdateOptions{})
+		if apierrors.IsConflict(err) {
+			conflicts++
+			updateConflictsTotal.Inc()
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update statefulset %q: %w", name, err)
+	}
+
+	if conflicts > 0 {
+		logger.Info("statefulset-update-conflict-retried", lager.Data{"name": name, "conflicts": conflicts})
+	}
+
+	rolloutID := fmt.Sprintf("%s-%d", name, statefulSet.Generation+1)
+
+	instances := make([]cf.InstanceRollout, *statefulSet.Spec.Replicas)
+	for i := range instances {
+		instances[i] = cf.InstanceRollout{Index: i, State: cf.RolloutStatePending}
+	}
+
+	m.mutex.Lock()
+	m.rollouts[rolloutID] = &cf.RolloutStatus{ID: rolloutID, Instances: instances}
+	m.active[name] = rolloutID
+	m.mutex.Unlock()
+
+	return rolloutID, nil
+}
+
+// RolloutIDForStatefulSet returns the most recently started rollout ID for
+// the named StatefulSet, or false if no rollout has been started for it.
+func (m *RolloutManager) RolloutIDForStatefulSet(name string) (string, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	rolloutID, ok := m.active[name]
+
+	return rolloutID, ok
+}
+
+// Observe records that a given instance ordinal has transitioned to state,
+// typically driven off pod status transitions seen by an informer watching
+// the StatefulSet's pods.
+func (m *RolloutManager) Observe(rolloutID string, index int, state string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	status, ok := m.rollouts[rolloutID]
+	if !ok {
+		return
+	}
+
+	for i := range status.Instances {
+		if status.Instances[i].Index == index {
+			status.Instances[i].State = state
+		}
+	}
+
+	status.Complete = allReady(status.Instances)
+}
+
+// Status returns the current progress of a previously started rollout.
+func (m *RolloutManager) Status(rolloutID string) (*cf.RolloutStatus, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	status, ok := m.rollouts[rolloutID]
+	if !ok {
+		return nil, apierrors.NewNotFound(appsv1.Resource("rollout"), rolloutID)
+	}
+
+	return status, nil
+}
+
+func allReady(instances []cf.InstanceRollout) bool {
+	for _, instance := range instances {
+		if instance.State != cf.RolloutStateReady {
+			return false
+		}
+	}
+	return true
+}
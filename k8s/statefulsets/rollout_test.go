@@ -0,0 +1,94 @@
+package statefulsets_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/k8s/statefulsets"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	testcore "k8s.io/client-go/testing"
+)
+
+var _ = Describe("RolloutManager", func() {
+	var (
+		client  *fake.Clientset
+		manager *statefulsets.RolloutManager
+		logger  *lagertest.TestLogger
+		mutate  func(*appsv1.StatefulSet)
+	)
+
+	BeforeEach(func() {
+		replicas := int32(2)
+		client = fake.NewSimpleClientset(&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+			},
+		})
+		manager = statefulsets.NewRolloutManager()
+		logger = lagertest.NewTestLogger("rollout-test")
+
+		mutate = func(statefulSet *appsv1.StatefulSet) {
+			statefulSet.Spec.Template.Spec.Containers = []corev1.Container{{Image: "my-image"}}
+		}
+	})
+
+	Context("when the update succeeds on the first attempt", func() {
+		It("applies the caller's mutation", func() {
+			_, err := manager.Start(context.Background(), client.AppsV1().StatefulSets("default"), "my-app", mutate, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			statefulSet, err := client.AppsV1().StatefulSets("default").Get(context.Background(), "my-app", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Image).To(Equal("my-image"))
+		})
+	})
+
+	Context("when the update conflicts twice before succeeding", func() {
+		BeforeEach(func() {
+			attempts := 0
+			client.PrependReactor("update", "statefulsets", func(action testcore.Action) (bool, runtime.Object, error) {
+				attempts++
+				if attempts <= 2 {
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "statefulsets"}, "my-app", nil)
+				}
+				return false, nil, nil
+			})
+		})
+
+		It("retries until the write succeeds without losing the caller's mutation", func() {
+			_, err := manager.Start(context.Background(), client.AppsV1().StatefulSets("default"), "my-app", mutate, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			statefulSet, err := client.AppsV1().StatefulSets("default").Get(context.Background(), "my-app", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Image).To(Equal("my-image"))
+
+			logs := logger.Logs()
+			Expect(logs).To(HaveLen(1))
+			Expect(logs[0].Message).To(Equal("rollout-test.statefulset-update-conflict-retried"))
+			Expect(logs[0].Data).To(HaveKeyWithValue("conflicts", float64(2)))
+		})
+	})
+
+	Context("when every attempt conflicts", func() {
+		BeforeEach(func() {
+			client.PrependReactor("update", "statefulsets", func(action testcore.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "statefulsets"}, "my-app", nil)
+			})
+		})
+
+		It("gives up and returns an error", func() {
+			_, err := manager.Start(context.Background(), client.AppsV1().StatefulSets("default"), "my-app", mutate, logger)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package statefulsets
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/lager"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RolloutObserver is the RolloutManager surface RolloutWatcher drives pod
+// status transitions into.
+type RolloutObserver interface {
+	Observe(rolloutID string, index int, state string)
+	RolloutIDForStatefulSet(name string) (string, bool)
+}
+
+// RolloutWatcher subscribes to Pod state transitions via a shared informer
+// instead of polling, and reports each StatefulSet-owned pod's readiness to
+// a RolloutObserver, so GET .../rollout/:id reflects real progress instead
+// of every instance staying Pending until the rollout manager is told
+// otherwise.
+type RolloutWatcher struct {
+	Clientset kubernetes.Interface
+	Observer  RolloutObserver
+	Logger    lager.Logger
+}
+
+// NewRolloutWatcher constructs a RolloutWatcher ready to Start.
+func NewRolloutWatcher(clientset kubernetes.Interface, observer RolloutObserver, logger lager.Logger) *RolloutWatcher {
+	return &RolloutWatcher{
+		Clientset: clientset,
+		Observer:  observer,
+		Logger:    logger,
+	}
+}
+
+// Start begins watching Pod events across the cluster via a SharedInformer
+// and blocks until stopCh is closed.
+func (w *RolloutWatcher) Start(resync time.Duration, stopCh <-chan struct{}) {
+	factory := informers.NewSharedInformerFactory(w.Clientset, resync)
+	podInformer := factory.Core().V1().Pods().Informer()
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.handle(obj) },
+		UpdateFunc: func(_, newObj interface{}) { w.handle(newObj) },
+	})
+
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	<-stopCh
+}
+
+func (w *RolloutWatcher) handle(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "StatefulSet" {
+		return
+	}
+
+	rolloutID, ok := w.Observer.RolloutIDForStatefulSet(owner.Name)
+	if !ok {
+		return
+	}
+
+	index, ok := ordinalFromPodName(pod.Name)
+	if !ok {
+		w.Logger.Error("failed-to-parse-pod-ordinal", nil, lager.Data{"pod": pod.Name})
+		return
+	}
+
+	state := cf.RolloutStateUpdating
+	if isPodReady(pod) {
+		state = cf.RolloutStateReady
+	}
+
+	w.Observer.Observe(rolloutID, index, state)
+}
+
+// ordinalFromPodName extracts the StatefulSet ordinal from a pod name of
+// the form "<statefulset-name>-<ordinal>".
+func ordinalFromPodName(name string) (int, bool) {
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return 0, false
+	}
+
+	ordinal, err := strconv.Atoi(name[i+1:])
+	if err != nil {
+		return 0, false
+	}
+
+	return ordinal, true
+}
+
+// isPodReady reports whether pod's PodReady condition is currently True.
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package statefulsets_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/eirini/k8s/statefulsets"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("RolloutWatcher", func() {
+	var (
+		client      *fake.Clientset
+		manager     *statefulsets.RolloutManager
+		watcher     *statefulsets.RolloutWatcher
+		stopCh      chan struct{}
+		statefulSet *appsv1.StatefulSet
+	)
+
+	BeforeEach(func() {
+		replicas := int32(2)
+		statefulSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "default", UID: "my-app-uid"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		}
+		client = fake.NewSimpleClientset(statefulSet)
+		manager = statefulsets.NewRolloutManager()
+		watcher = statefulsets.NewRolloutWatcher(client, manager, lagertest.NewTestLogger("rollout-watcher-test"))
+	})
+
+	JustBeforeEach(func() {
+		stopCh = make(chan struct{})
+		go watcher.Start(10*time.Millisecond, stopCh)
+	})
+
+	AfterEach(func() {
+		close(stopCh)
+	})
+
+	It("completes the rollout once every instance's pod becomes ready", func() {
+		rolloutID, err := manager.Start(context.Background(), client.AppsV1().StatefulSets("default"), "my-app", func(*appsv1.StatefulSet) {}, lagertest.NewTestLogger("rollout-test"))
+		Expect(err).NotTo(HaveOccurred())
+
+		controller := true
+		for i := 0; i < 2; i++ {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("my-app-%d", i),
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "StatefulSet", Name: "my-app", UID: statefulSet.UID, Controller: &controller},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+
+			_, err := client.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Eventually(func() bool {
+			status, err := manager.Status(rolloutID)
+			return err == nil && status.Complete
+		}, time.Second).Should(BeTrue())
+	})
+})
@@ -0,0 +1,83 @@
+// Package cf holds the Cloud Controller-facing request/response shapes that
+// the handler package translates HTTP bodies into before handing them to
+// the Bifrost.
+package cf
+
+import (
+	"encoding/json"
+)
+
+// DesireLRPRequest is the body of a `Desire an app` request from Cloud
+// Controller.
+type DesireLRPRequest struct {
+	GUID             string            `json:"guid"`
+	ProcessGUID      string            `json:"process_guid"`
+	Version          string            `json:"version"`
+	ProcessType      string            `json:"process_type"`
+	AppGUID          string            `json:"app_guid"`
+	AppName          string            `json:"app_name"`
+	SpaceGUID        string            `json:"space_guid"`
+	SpaceName        string            `json:"space_name"`
+	OrganizationGUID string            `json:"organization_guid"`
+	OrganizationName string            `json:"organization_name"`
+	PlacementTags    []string          `json:"placement_tags"`
+	EgressRules      []json.RawMessage `json:"egress_rules"`
+	Lifecycle        Lifecycle         `json:"lifecycle"`
+	Environment      map[string]string `json:"environment"`
+	NumInstances     int               `json:"instances"`
+	MemoryMB         int               `json:"memory_mb"`
+	CPUWeight        uint8             `json:"cpu_weight"`
+	DiskMB           int               `json:"disk_mb"`
+	LastUpdated      string            `json:"last_updated"`
+
+	HealthCheckType         string `json:"health_check_type"`
+	HealthCheckHTTPEndpoint string `json:"health_check_http_endpoint"`
+	HealthCheckTimeoutMs    uint   `json:"health_check_timeout_ms"`
+	StartTimeoutMs          uint   `json:"start_timeout_ms"`
+
+	Ports        []int32       `json:"ports"`
+	VolumeMounts []VolumeMount `json:"volume_mounts"`
+
+	// LRP carries the raw request body, verbatim, so it can be replayed
+	// against the Diego LRP schema without Eirini having to round-trip
+	// every field it doesn't otherwise need.
+	LRP string `json:"-"`
+}
+
+// Lifecycle describes how an app's droplet/image should be run.
+type Lifecycle struct {
+	BuildpackLifecycle *BuildpackLifecycle `json:"buildpack_lifecycle,omitempty"`
+	DockerLifecycle    *DockerLifecycle    `json:"docker_lifecycle,omitempty"`
+}
+
+// BuildpackLifecycle is the buildpack-staged variant of Lifecycle.
+type BuildpackLifecycle struct {
+	StartCommand string `json:"start_command"`
+}
+
+// DockerLifecycle is the docker-image variant of Lifecycle.
+type DockerLifecycle struct {
+	Image string `json:"image"`
+}
+
+// VolumeMount describes a single CF volume service binding.
+type VolumeMount struct {
+	MountDir string `json:"mount_dir"`
+	VolumeID string `json:"volume_id"`
+}
+
+// Instance reports the state of a single app instance for the
+// `Get Instances` endpoint.
+type Instance struct {
+	Index          int    `json:"index"`
+	Since          int64  `json:"since"`
+	State          string `json:"state"`
+	PlacementError string `json:"placement_error,omitempty"`
+}
+
+// UpdateDesiredLRPRequest is the body of an `Update an app` request.
+type UpdateDesiredLRPRequest struct {
+	GUID    string            `json:"guid"`
+	Version string            `json:"version"`
+	Update  *AppUpdateRequest `json:"update"`
+}
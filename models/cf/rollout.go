@@ -0,0 +1,58 @@
+package cf
+
+// AppUpdateRequest is the body of an `Update an app` request. It supersedes
+// a bare instance-count change by also allowing the image, environment,
+// routes, resource limits and health-check configuration to be rolled out
+// together as a single StatefulSet rollout.
+type AppUpdateRequest struct {
+	Instances   *int32            `json:"instances,omitempty"`
+	Image       string            `json:"image,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+	Routes      []string          `json:"routes,omitempty"`
+	Resources   *Resources        `json:"resources,omitempty"`
+	HealthCheck *HealthCheck      `json:"health_check,omitempty"`
+}
+
+// GetInstances returns the requested instance count, or 0 if unset.
+func (u *AppUpdateRequest) GetInstances() int32 {
+	if u == nil || u.Instances == nil {
+		return 0
+	}
+	return *u.Instances
+}
+
+// Resources carries the per-instance resource limits Cloud Controller can
+// change as part of a rollout.
+type Resources struct {
+	MemoryMB  int   `json:"memory_mb,omitempty"`
+	DiskMB    int   `json:"disk_mb,omitempty"`
+	CPUWeight uint8 `json:"cpu_weight,omitempty"`
+}
+
+// HealthCheck carries the instance health-check configuration.
+type HealthCheck struct {
+	Type      string `json:"type,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	TimeoutMs uint   `json:"timeout_ms,omitempty"`
+}
+
+// RolloutStatus reports the progress of an in-flight rolling update, one
+// entry per instance ordinal.
+type RolloutStatus struct {
+	ID        string            `json:"id"`
+	Complete  bool              `json:"complete"`
+	Instances []InstanceRollout `json:"instances"`
+}
+
+// InstanceRollout is the rollout progress of a single instance.
+type InstanceRollout struct {
+	Index int    `json:"index"`
+	State string `json:"state"`
+}
+
+// Rollout instance states.
+const (
+	RolloutStatePending   = "PENDING"
+	RolloutStateUpdating  = "UPDATING"
+	RolloutStateReady     = "READY"
+)
@@ -0,0 +1,48 @@
+// Package retry provides the exponential-backoff retry shared by the route
+// emitters and the crash-report event sinks, so both back off failed
+// deliveries the same way instead of each carrying its own copy.
+package retry
+
+import "time"
+
+// Config configures the exponential backoff Do uses between failed attempts
+// at fn.
+type Config struct {
+	MaxAttempts  int           `yaml:"max_attempts"`
+	InitialDelay time.Duration `yaml:"initial_delay"`
+	MaxDelay     time.Duration `yaml:"max_delay"`
+}
+
+// Do calls fn until it succeeds or cfg's attempt budget is spent, backing
+// off exponentially between attempts up to cfg.MaxDelay. A zero-value
+// Config tries once, with no delay.
+func Do(cfg Config, fn func() error) error {
+	attempts := cfg.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	delay := cfg.InitialDelay
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if attempt == attempts-1 {
+			break
+		}
+
+		if delay > 0 {
+			time.Sleep(delay)
+			delay *= 2
+
+			if cfg.MaxDelay > 0 && delay > cfg.MaxDelay {
+				delay = cfg.MaxDelay
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,101 @@
+package route
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// batchingEmitter coalesces Emit calls into a single Emit against backend,
+// flushed window after the first pending message arrived, or as soon as
+// maxSize messages are pending, whichever comes first. An Emit call that
+// carries the batch past maxSize is flushed whole, so a flush can hold more
+// than maxSize messages. This keeps a burst of single-pod events, each emitted as it
+// happens, from fanning out one message per pod to the backend.
+type batchingEmitter struct {
+	backend Emitter
+	window  time.Duration
+	maxSize int
+	logger  lager.Logger
+
+	mutex   sync.Mutex
+	pending []RegistryMessage
+	timer   *time.Timer
+}
+
+// newBatchingEmitter wraps backend so that Emit calls are coalesced over
+// window, or maxSize messages, whichever comes first. maxSize <= 0 disables
+// the size-based flush.
+func newBatchingEmitter(backend Emitter, window time.Duration, maxSize int, logger lager.Logger) Emitter {
+	return &batchingEmitter{
+		backend: backend,
+		window:  window,
+		maxSize: maxSize,
+		logger:  logger.Session("batching-emitter"),
+	}
+}
+
+func (b *batchingEmitter) Emit(messages []RegistryMessage) error {
+	b.mutex.Lock()
+
+	b.pending = append(b.pending, messages...)
+
+	if b.maxSize > 0 && len(b.pending) >= b.maxSize {
+		pending := b.takePendingLocked()
+		b.mutex.Unlock()
+
+		return b.backend.Emit(pending)
+	}
+
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+
+	b.mutex.Unlock()
+
+	return nil
+}
+
+// flush is invoked by the batch window timer, so any backend error has
+// nowhere to return to and is logged instead.
+func (b *batchingEmitter) flush() {
+	b.mutex.Lock()
+	pending := b.takePendingLocked()
+	b.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if err := b.backend.Emit(pending); err != nil {
+		b.logger.Error("failed-to-flush-batch", err, lager.Data{"messages": len(pending)})
+	}
+}
+
+func (b *batchingEmitter) takePendingLocked() []RegistryMessage {
+	pending := b.pending
+	b.pending = nil
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	return pending
+}
+
+// Close flushes any buffered messages before closing the backend.
+func (b *batchingEmitter) Close() error {
+	b.mutex.Lock()
+	pending := b.takePendingLocked()
+	b.mutex.Unlock()
+
+	if len(pending) > 0 {
+		if err := b.backend.Emit(pending); err != nil {
+			return err
+		}
+	}
+
+	return b.backend.Close()
+}
@@ -0,0 +1,77 @@
+package route
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type spyEmitter struct {
+	mutex  sync.Mutex
+	emits  [][]RegistryMessage
+	closed bool
+}
+
+func (s *spyEmitter) Emit(messages []RegistryMessage) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.emits = append(s.emits, messages)
+	return nil
+}
+
+func (s *spyEmitter) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *spyEmitter) emitCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.emits)
+}
+
+var _ = Describe("batchingEmitter", func() {
+	var (
+		backend *spyEmitter
+		batcher Emitter
+	)
+
+	BeforeEach(func() {
+		backend = &spyEmitter{}
+	})
+
+	It("flushes once maxSize is reached, without waiting for the window", func() {
+		batcher = newBatchingEmitter(backend, time.Hour, 2, lagertest.NewTestLogger("batch-test"))
+
+		Expect(batcher.Emit([]RegistryMessage{{App: "a"}})).To(Succeed())
+		Expect(backend.emitCount()).To(Equal(0))
+
+		Expect(batcher.Emit([]RegistryMessage{{App: "b"}})).To(Succeed())
+		Eventually(backend.emitCount).Should(Equal(1))
+		Expect(backend.emits[0]).To(HaveLen(2))
+	})
+
+	It("flushes once the window elapses, even below maxSize", func() {
+		batcher = newBatchingEmitter(backend, 10*time.Millisecond, 100, lagertest.NewTestLogger("batch-test"))
+
+		Expect(batcher.Emit([]RegistryMessage{{App: "a"}})).To(Succeed())
+
+		Eventually(backend.emitCount).Should(Equal(1))
+		Expect(backend.emits[0]).To(HaveLen(1))
+	})
+
+	It("flushes any buffered messages and closes the backend on Close", func() {
+		batcher = newBatchingEmitter(backend, time.Hour, 100, lagertest.NewTestLogger("batch-test"))
+
+		Expect(batcher.Emit([]RegistryMessage{{App: "a"}})).To(Succeed())
+		Expect(batcher.Close()).To(Succeed())
+
+		Expect(backend.emitCount()).To(Equal(1))
+		Expect(backend.closed).To(BeTrue())
+	})
+})
@@ -0,0 +1,112 @@
+package route
+
+import (
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/eirini/retry"
+	"gopkg.in/yaml.v2"
+)
+
+// EmitterType selects which Emitter backend route-pod-informer wires up.
+type EmitterType string
+
+const (
+	// EmitterTypeNATS publishes registration messages onto the NATS
+	// message bus gorouter subscribes to. This is the default, for
+	// backwards compatibility with existing deployments. No
+	// unregistration messages are published.
+	EmitterTypeNATS EmitterType = "nats"
+
+	// EmitterTypeHTTP posts Diego-BBS-compatible webhook batches to a
+	// configurable HTTP(S) endpoint.
+	EmitterTypeHTTP EmitterType = "http"
+
+	// EmitterTypeGRPC streams RegistryMessage batches to a gRPC endpoint,
+	// e.g. an Istio/Envoy xDS bridge standing in for gorouter.
+	EmitterTypeGRPC EmitterType = "grpc"
+)
+
+// TLSConfig is the TLS material for a single Emitter backend.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	CAFile   string `yaml:"ca_file"`
+}
+
+// RetryConfig configures the exponential backoff a backend uses when
+// delivery fails.
+type RetryConfig = retry.Config
+
+// EmitterConfig selects and configures the route-emitter backend.
+type EmitterConfig struct {
+	Type EmitterType `yaml:"type"`
+
+	// BatchWindow coalesces a burst of podUpdateHandler events into a
+	// single batched publish, instead of one message per pod.
+	BatchWindow time.Duration `yaml:"batch_window"`
+	BatchSize   int           `yaml:"batch_size"`
+
+	Retry RetryConfig `yaml:"retry"`
+	TLS   TLSConfig   `yaml:"tls"`
+
+	NATS NATSConfig `yaml:"nats"`
+	HTTP HTTPConfig `yaml:"http"`
+	GRPC GRPCConfig `yaml:"grpc"`
+}
+
+// NATSConfig configures the NATS backend.
+type NATSConfig struct {
+	IP       string `yaml:"ip"`
+	Port     int    `yaml:"port"`
+	Password string `yaml:"password"`
+}
+
+// HTTPConfig configures the Diego-BBS-compatible HTTP webhook backend.
+type HTTPConfig struct {
+	URL string `yaml:"url"`
+}
+
+// GRPCConfig configures the gRPC streaming backend.
+type GRPCConfig struct {
+	Address string `yaml:"address"`
+}
+
+// Config is the route-collector configuration file.
+type Config struct {
+	ConfigPath string `yaml:"config_path"`
+	Namespace  string `yaml:"namespace"`
+
+	// NatsIP, NatsPort and NatsPassword are kept for backwards
+	// compatibility with config files predating RouteEmitter; they are
+	// used to populate RouteEmitter.NATS when RouteEmitter.Type is unset.
+	NatsIP       string `yaml:"nats_ip"`
+	NatsPort     int    `yaml:"nats_port"`
+	NatsPassword string `yaml:"nats_password"`
+
+	RouteEmitter EmitterConfig `yaml:"route_emitter"`
+}
+
+// ReadConfig loads and defaults a route-collector Config from path.
+func ReadConfig(path string) (*Config, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(contents, &cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.RouteEmitter.Type == "" {
+		cfg.RouteEmitter.Type = EmitterTypeNATS
+		cfg.RouteEmitter.NATS = NATSConfig{
+			IP:       cfg.NatsIP,
+			Port:     cfg.NatsPort,
+			Password: cfg.NatsPassword,
+		}
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,51 @@
+package route
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Emitter delivers batches of route registry messages to whatever is
+// playing the role of gorouter for this deployment.
+type Emitter interface {
+	Emit(messages []RegistryMessage) error
+	Close() error
+}
+
+// NewEmitter selects and constructs the Emitter backend configured by cfg,
+// wrapping it in a coalescing batch window when cfg.BatchWindow is set so
+// that bursts of single-pod updates don't fan out one message per pod.
+func NewEmitter(cfg EmitterConfig, logger lager.Logger) (Emitter, error) {
+	backend, err := newBackend(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.BatchWindow > 0 {
+		return newBatchingEmitter(backend, cfg.BatchWindow, cfg.BatchSize, logger), nil
+	}
+
+	return backend, nil
+}
+
+func newBackend(cfg EmitterConfig, logger lager.Logger) (Emitter, error) {
+	switch cfg.Type {
+	case "", EmitterTypeNATS:
+		return NewNATSEmitter(cfg.NATS.IP, cfg.NATS.Port, cfg.NATS.Password, logger)
+	case EmitterTypeHTTP:
+		return NewHTTPEmitter(cfg.HTTP, cfg.TLS, cfg.Retry, logger), nil
+	case EmitterTypeGRPC:
+		return NewGRPCEmitter(cfg.GRPC, cfg.TLS, cfg.Retry, logger)
+	default:
+		return nil, fmt.Errorf("unknown route emitter type: %q", cfg.Type)
+	}
+}
+
+// NewEmitterFromConfig is kept for existing NATS-only deployments that
+// construct an Emitter directly rather than going through NewEmitter.
+//
+// Deprecated: use NewEmitter with an EmitterConfig instead.
+func NewEmitterFromConfig(natsIP string, natsPort int, natsPassword string, logger lager.Logger) (Emitter, error) {
+	return NewNATSEmitter(natsIP, natsPort, natsPassword, logger)
+}
@@ -0,0 +1,118 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/route/routepb"
+	"code.cloudfoundry.org/lager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+//go:generate protoc --go_out=plugins=grpc:. routepb/route.proto
+
+// grpcEmitter streams RegistryMessage batches to a gRPC endpoint over a
+// long-lived Publish stream, e.g. an Istio/Envoy xDS bridge standing in for
+// gorouter. A stream that fails to Send is broken for good, so Emit
+// transparently reopens it against the same connection rather than
+// retrying Send on a dead stream.
+type grpcEmitter struct {
+	conn   *grpc.ClientConn
+	retry  RetryConfig
+	logger lager.Logger
+
+	mutex  sync.Mutex
+	stream routepb.RouteEmitter_PublishClient
+}
+
+// NewGRPCEmitter dials cfg.Address and opens the Publish stream that
+// RegistryMessage batches are pushed onto.
+func NewGRPCEmitter(cfg GRPCConfig, tlsCfg TLSConfig, retry RetryConfig, logger lager.Logger) (Emitter, error) {
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+
+	tlsConfig, err := buildTLSConfig(tlsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build grpc tls config: %w", err)
+	}
+	if tlsConfig != nil {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	}
+
+	conn, err := grpc.Dial(cfg.Address, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial route emitter %q: %w", cfg.Address, err)
+	}
+
+	stream, err := routepb.NewRouteEmitterClient(conn).Publish(context.Background())
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open route emitter publish stream: %w", err)
+	}
+
+	return &grpcEmitter{
+		conn:   conn,
+		stream: stream,
+		retry:  retry,
+		logger: logger.Session("grpc-emitter"),
+	}, nil
+}
+
+func (e *grpcEmitter) Emit(messages []RegistryMessage) error {
+	batch := &routepb.RouteUpdateBatch{Updates: make([]*routepb.RouteUpdate, len(messages))}
+	for i, message := range messages {
+		batch.Updates[i] = &routepb.RouteUpdate{
+			Host:                 message.Host,
+			Port:                 uint32(message.Port),
+			Uris:                 message.URIs,
+			App:                  message.App,
+			PrivateInstanceId:    message.PrivateInstanceID,
+			PrivateInstanceIndex: message.PrivateInstanceIndex,
+			RouteServiceUrl:      message.RouteServiceURL,
+			Tags:                 message.Tags,
+		}
+	}
+
+	err := withRetry(e.retry, func() error {
+		return e.sendBatch(batch)
+	})
+	if err != nil {
+		e.logger.Error("failed-to-send-batch", err, lager.Data{"messages": len(messages)})
+	}
+
+	return err
+}
+
+// sendBatch sends batch on the current stream, transparently reopening a
+// fresh Publish stream against e.conn and retrying once if the current one
+// turns out to be broken.
+func (e *grpcEmitter) sendBatch(batch *routepb.RouteUpdateBatch) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if err := e.stream.Send(batch); err != nil {
+		stream, reopenErr := routepb.NewRouteEmitterClient(e.conn).Publish(context.Background())
+		if reopenErr != nil {
+			return fmt.Errorf("failed to reopen route emitter publish stream: %w", reopenErr)
+		}
+
+		e.stream = stream
+
+		return e.stream.Send(batch)
+	}
+
+	return nil
+}
+
+func (e *grpcEmitter) Close() error {
+	e.mutex.Lock()
+	_, err := e.stream.CloseAndRecv()
+	e.mutex.Unlock()
+
+	if closeErr := e.conn.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// httpRequestTimeout bounds a single webhook POST, independent of how many
+// attempts RetryConfig allows.
+const httpRequestTimeout = 10 * time.Second
+
+// httpBatch is the Diego-BBS-compatible webhook body: a plain batch of
+// registry messages, rather than gorouter's one-message-per-NATS-publish
+// shape.
+type httpBatch struct {
+	Messages []RegistryMessage `json:"messages"`
+}
+
+// httpEmitter posts batches of RegistryMessage as a Diego-BBS-compatible
+// webhook to a configured HTTP(S) endpoint, retrying failed deliveries.
+type httpEmitter struct {
+	url    string
+	client *http.Client
+	retry  RetryConfig
+	logger lager.Logger
+}
+
+// NewHTTPEmitter constructs an Emitter that posts RegistryMessage batches
+// to cfg.URL.
+func NewHTTPEmitter(cfg HTTPConfig, tlsCfg TLSConfig, retry RetryConfig, logger lager.Logger) Emitter {
+	client := &http.Client{Timeout: httpRequestTimeout}
+
+	if tlsConfig, err := buildTLSConfig(tlsCfg); err == nil && tlsConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return &httpEmitter{
+		url:    cfg.URL,
+		client: client,
+		retry:  retry,
+		logger: logger.Session("http-emitter"),
+	}
+}
+
+func (e *httpEmitter) Emit(messages []RegistryMessage) error {
+	payload, err := json.Marshal(httpBatch{Messages: messages})
+	if err != nil {
+		return fmt.Errorf("failed to marshal registry message batch: %w", err)
+	}
+
+	err = withRetry(e.retry, func() error {
+		resp, postErr := e.client.Post(e.url, "application/json", bytes.NewReader(payload))
+		if postErr != nil {
+			return postErr
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("route webhook %q returned status %d", e.url, resp.StatusCode)
+		}
+
+		return nil
+	})
+	if err != nil {
+		e.logger.Error("failed-to-post-batch", err, lager.Data{"messages": len(messages)})
+	}
+
+	return err
+}
+
+func (e *httpEmitter) Close() error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/nats-io/nats.go"
+)
+
+// registerSubject is the NATS subject gorouter subscribes to for route
+// registrations.
+const registerSubject = "router.register"
+
+// natsEmitter publishes RegistryMessage batches onto the NATS subject
+// gorouter subscribes to. This is the original Emitter backend, and
+// remains the default for backwards compatibility with existing
+// deployments.
+type natsEmitter struct {
+	conn   *nats.Conn
+	logger lager.Logger
+}
+
+// NewNATSEmitter connects to the NATS cluster at ip:port and returns an
+// Emitter that publishes onto it.
+func NewNATSEmitter(ip string, port int, password string, logger lager.Logger) (Emitter, error) {
+	url := fmt.Sprintf("nats://%s:%d", ip, port)
+
+	opts := []nats.Option{}
+	if password != "" {
+		opts = append(opts, nats.Token(password))
+	}
+
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	return &natsEmitter{conn: conn, logger: logger.Session("nats-emitter")}, nil
+}
+
+// Emit publishes each message individually onto the register subject, as
+// gorouter expects one NATS message per route registration.
+func (e *natsEmitter) Emit(messages []RegistryMessage) error {
+	for _, message := range messages {
+		payload, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to marshal registry message: %w", err)
+		}
+
+		if err := e.conn.Publish(registerSubject, payload); err != nil {
+			e.logger.Error("failed-to-publish", err)
+			return fmt.Errorf("failed to publish registry message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *natsEmitter) Close() error {
+	e.conn.Close()
+	return nil
+}
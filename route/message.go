@@ -0,0 +1,19 @@
+package route
+
+// RegistryMessage is a single gorouter route-registration record, the
+// payload every Emitter backend ultimately delivers.
+type RegistryMessage struct {
+	Host                 string            `json:"host"`
+	Port                 uint16            `json:"port"`
+	URIs                 []string          `json:"uris"`
+	App                  string            `json:"app"`
+	PrivateInstanceID    string            `json:"private_instance_id"`
+	PrivateInstanceIndex string            `json:"private_instance_index"`
+	RouteServiceURL      string            `json:"route_service_url,omitempty"`
+	Tags                 map[string]string `json:"tags,omitempty"`
+}
+
+// Unregister names a RegistryMessage for an instance that has gone away. It
+// is an alias, so the Emitter backends do not tell it apart from a
+// registration: the NATS backend publishes it on the register subject.
+type Unregister = RegistryMessage
@@ -0,0 +1,10 @@
+package route
+
+import "code.cloudfoundry.org/eirini/retry"
+
+// withRetry calls fn until it succeeds or cfg's attempt budget is spent,
+// backing off exponentially between attempts up to cfg.MaxDelay. A
+// zero-value RetryConfig tries once, with no delay.
+func withRetry(cfg RetryConfig, fn func() error) error {
+	return retry.Do(cfg, fn)
+}